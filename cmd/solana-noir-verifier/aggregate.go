@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+
+	"solana-noir-verifier/pkg/aggregate"
+	"solana-noir-verifier/pkg/proofsystem"
+)
+
+// newAggregateCmd wires pkg/aggregate's recursive verifier into the CLI:
+// given an inner circuit's cs.bin/vk.bin and N of its proofs, it emits one
+// outer Groth16 proof that pkg/solana's existing export-solana path can
+// turn into a single on-chain check covering all N. The outer layer is
+// always groth16 regardless of --backend: pkg/aggregate's circuit only
+// verifies inner Groth16 proofs, and recursing through an emulated PLONK
+// verifier is a separate, unstarted piece of work.
+func newAggregateCmd() *cobra.Command {
+	var outPaths string
+
+	cmd := &cobra.Command{
+		Use:   "aggregate <inner-cs.bin> <inner-vk.bin> <proof.bin:publics.json>...",
+		Short: "Recursively verify N Groth16 proofs over one inner VK and emit a single outer proof",
+		Args:  cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inner := proofsystem.Groth16{}
+
+			innerCS, err := readConstraintSystem(args[0], inner)
+			if err != nil {
+				return fmt.Errorf("read inner constraint system: %w", err)
+			}
+			innerVK, err := readVerifyingKey(args[1], inner)
+			if err != nil {
+				return fmt.Errorf("read inner verifying key: %w", err)
+			}
+			innerVKBN254, ok := innerVK.(*groth16_bn254.VerifyingKey)
+			if !ok {
+				return fmt.Errorf("inner verifying key is %T, not BN254", innerVK)
+			}
+
+			proofs, publicInputs, err := readAggregateInputs(args[2:], inner)
+			if err != nil {
+				return err
+			}
+
+			circuit, publicHash, err := aggregate.BuildWitness(innerVKBN254, proofs, publicInputs)
+			if err != nil {
+				return fmt.Errorf("build aggregation witness: %w", err)
+			}
+
+			outerCS, err := inner.Compile(cliCurve, aggregate.NewCircuit(innerCS, len(proofs)))
+			if err != nil {
+				return fmt.Errorf("compile aggregation circuit: %w", err)
+			}
+			outerPK, outerVK, err := inner.Setup(outerCS)
+			if err != nil {
+				return fmt.Errorf("setup aggregation circuit: %w", err)
+			}
+
+			full, err := frontend.NewWitness(circuit, cliCurve.ScalarField())
+			if err != nil {
+				return fmt.Errorf("build aggregation witness: %w", err)
+			}
+			outerProof, err := inner.Prove(outerCS, outerPK, full)
+			if err != nil {
+				return fmt.Errorf("prove aggregation: %w", err)
+			}
+
+			parts := strings.Split(outPaths, ",")
+			if len(parts) != 3 {
+				return fmt.Errorf("-o must be \"pk.bin,vk.bin,proof.bin\", got %q", outPaths)
+			}
+			pkPath, vkPath, proofPath := parts[0], parts[1], parts[2]
+
+			if err := writeProvingKey(pkPath, outerPK); err != nil {
+				return err
+			}
+			if err := writeVerifyingKey(vkPath, outerVK); err != nil {
+				return err
+			}
+			if err := writeProof(proofPath, outerProof); err != nil {
+				return err
+			}
+
+			// publicHash is bound in-circuit by aggregate.Circuit.Define,
+			// so the outer proof only verifies if it matches the public
+			// inputs actually aggregated; print it so a caller can check
+			// it against the public inputs they expect before treating
+			// this aggregate proof as a commitment to a specific
+			// statement set.
+			fmt.Printf("public input hash: %s\n", publicHash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outPaths, "out", "o", "agg_pk.bin,agg_vk.bin,agg_proof.bin", "comma-separated output paths for the outer proving key, verifying key, and proof")
+	return cmd
+}
+
+// readAggregateInputs parses the "proof.bin:publics.json" pairs making up
+// the batch to aggregate.
+func readAggregateInputs(pairs []string, backend proofsystem.System) ([]*groth16_bn254.Proof, [][]*big.Int, error) {
+	proofs := make([]*groth16_bn254.Proof, len(pairs))
+	publicInputs := make([][]*big.Int, len(pairs))
+	for i, pair := range pairs {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			return nil, nil, fmt.Errorf("expected \"proof.bin:publics.json\", got %q", pair)
+		}
+		proof, err := readProof(parts[0], backend)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read proof %d: %w", i, err)
+		}
+		proofBN254, ok := proof.(*groth16_bn254.Proof)
+		if !ok {
+			return nil, nil, fmt.Errorf("proof %d is %T, not BN254", i, proof)
+		}
+		values, err := readPublicInputsJSON(parts[1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("read public inputs %d: %w", i, err)
+		}
+		proofs[i] = proofBN254
+		publicInputs[i] = values
+	}
+	return proofs, publicInputs, nil
+}
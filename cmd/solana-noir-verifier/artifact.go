@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+
+	"solana-noir-verifier/pkg/proofsystem"
+)
+
+// All artifacts on disk are BN254-only for now: groth16-solana's alt_bn128
+// syscalls are BN254-specific, so there is no reason yet for the CLI (as
+// opposed to the curve-parameterized benchmark harness in pkg/curves) to
+// carry the extra ReadFrom/WriteTo plumbing for other curves.
+var cliCurve = ecc.BN254
+
+// readConstraintSystem loads a compiled constraint system written by
+// `compile`. Which concrete type to allocate (R1CS for groth16, SCS for
+// plonk) depends on backend, since constraint.ConstraintSystem's ReadFrom
+// is only meaningful on an already-shaped value.
+func readConstraintSystem(path string, backend proofsystem.System) (constraint.ConstraintSystem, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cs, err := blankConstraintSystem(backend)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cs.ReadFrom(f); err != nil {
+		return nil, fmt.Errorf("read constraint system: %w", err)
+	}
+	return cs, nil
+}
+
+func blankConstraintSystem(backend proofsystem.System) (constraint.ConstraintSystem, error) {
+	switch backend.Name() {
+	case "groth16":
+		return groth16.NewCS(cliCurve), nil
+	case "plonk":
+		return plonk.NewCS(cliCurve), nil
+	default:
+		return nil, fmt.Errorf("no constraint system type for backend %q", backend.Name())
+	}
+}
+
+// writeConstraintSystem persists a compiled constraint system for later
+// `setup`/`prove` invocations via gnark's own WriteTo.
+func writeConstraintSystem(path string, cs constraint.ConstraintSystem) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = cs.WriteTo(f)
+	return err
+}
+
+func writeProvingKey(path string, pk proofsystem.ProvingKey) error {
+	return writeWriterTo(path, pk)
+}
+
+func readProvingKey(path string, backend proofsystem.System) (proofsystem.ProvingKey, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pk proofsystem.ProvingKey
+	switch backend.Name() {
+	case "groth16":
+		pk = groth16.NewProvingKey(cliCurve)
+	case "plonk":
+		pk = plonk.NewProvingKey(cliCurve)
+	default:
+		return nil, fmt.Errorf("no proving key type for backend %q", backend.Name())
+	}
+	if _, err := pk.(io.ReaderFrom).ReadFrom(f); err != nil {
+		return nil, fmt.Errorf("read proving key: %w", err)
+	}
+	return pk, nil
+}
+
+func writeVerifyingKey(path string, vk proofsystem.VerifyingKey) error {
+	return writeWriterTo(path, vk)
+}
+
+func readVerifyingKey(path string, backend proofsystem.System) (proofsystem.VerifyingKey, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var vk proofsystem.VerifyingKey
+	switch backend.Name() {
+	case "groth16":
+		vk = groth16.NewVerifyingKey(cliCurve)
+	case "plonk":
+		vk = plonk.NewVerifyingKey(cliCurve)
+	default:
+		return nil, fmt.Errorf("no verifying key type for backend %q", backend.Name())
+	}
+	if _, err := vk.(io.ReaderFrom).ReadFrom(f); err != nil {
+		return nil, fmt.Errorf("read verifying key: %w", err)
+	}
+	return vk, nil
+}
+
+func writeProof(path string, proof proofsystem.Proof) error {
+	return writeWriterTo(path, proof)
+}
+
+// chainProofPath derives the chain-consumable proof sidecar's path from
+// proof.bin's own path (proof.bin -> proof.chain.bin), the same
+// "swap the extension" convention jsonSidecar/publicsPath use.
+func chainProofPath(proofPath string) string {
+	return strings.TrimSuffix(proofPath, ".bin") + ".chain.bin"
+}
+
+// writeChainProof writes proof through sys.MarshalProof - the byte
+// layout the generated Solana/EVM verifiers actually expect - as a
+// sidecar next to proof.bin, which stays in gnark's own WriteTo format
+// for `verify` to ReadFrom.
+func writeChainProof(path string, sys proofsystem.System, proof proofsystem.Proof) error {
+	b, err := sys.MarshalProof(proof)
+	if err != nil {
+		return fmt.Errorf("marshal chain-consumable proof: %w", err)
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+func readProof(path string, backend proofsystem.System) (proofsystem.Proof, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var proof proofsystem.Proof
+	switch backend.Name() {
+	case "groth16":
+		proof = groth16.NewProof(cliCurve)
+	case "plonk":
+		proof = plonk.NewProof(cliCurve)
+	default:
+		return nil, fmt.Errorf("no proof type for backend %q", backend.Name())
+	}
+	if _, err := proof.(io.ReaderFrom).ReadFrom(f); err != nil {
+		return nil, fmt.Errorf("read proof: %w", err)
+	}
+	return proof, nil
+}
+
+// jsonSidecar derives a snarkjs-schema JSON sidecar's path from a binary
+// artifact's path (vk.bin -> vk.json, proof.bin -> proof.json), the same
+// "swap the extension" convention metaPath and publicsPath use.
+func jsonSidecar(binPath string) string {
+	return strings.TrimSuffix(binPath, ".bin") + ".json"
+}
+
+// writeWriterTo persists any gnark artifact that implements io.WriterTo -
+// proving keys, verifying keys and proofs all do, for both backends.
+func writeWriterTo(path string, v interface{}) error {
+	w, ok := v.(io.WriterTo)
+	if !ok {
+		return fmt.Errorf("%T does not implement io.WriterTo", v)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = w.WriteTo(f)
+	return err
+}
+
+// PublicInputsJSON is the stable schema for publics.json: one decimal
+// field-element string per public input, in circuit order, so the same
+// file can be posted as a Solana transaction argument.
+type PublicInputsJSON struct {
+	Values []string `json:"values"`
+}
+
+func writePublicInputsJSON(path string, values []*big.Int) error {
+	pubs := PublicInputsJSON{Values: make([]string, len(values))}
+	for i, v := range values {
+		pubs.Values[i] = v.String()
+	}
+	b, err := json.MarshalIndent(pubs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+func readPublicInputsJSON(path string) ([]*big.Int, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pubs PublicInputsJSON
+	if err := json.Unmarshal(b, &pubs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	values := make([]*big.Int, len(pubs.Values))
+	for i, s := range pubs.Values {
+		v, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("%s: value %d (%q) is not a valid field element", path, i, s)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// publicWitnessAssignment is a bare frontend.Circuit whose only purpose is
+// to give frontend.NewWitness something to build a public-only witness
+// vector from at verify time, when the original circuit struct isn't
+// available. It is never compiled (Define is never called by `verify`).
+type publicWitnessAssignment struct {
+	Values []frontend.Variable `gnark:",public"`
+}
+
+func (a *publicWitnessAssignment) Define(api frontend.API) error { return nil }
+
+// publicWitness builds a public-only witness.Witness from decoded
+// publics.json values, in the format backend.Verify expects.
+func publicWitness(values []*big.Int) (witness.Witness, error) {
+	assignment := &publicWitnessAssignment{Values: make([]frontend.Variable, len(values))}
+	for i, v := range values {
+		assignment.Values[i] = v
+	}
+	full, err := frontend.NewWitness(assignment, cliCurve.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+	return full.Public()
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"solana-noir-verifier/pkg/acir"
+)
+
+func newCompileCmd() *cobra.Command {
+	var outPath string
+
+	cmd := &cobra.Command{
+		Use:   "compile <circuit.acir>",
+		Short: "Compile an ACIR-shaped circuit (pkg/acir's own wire format, not nargo's) to a constraint system (R1CS for groth16, SCS for plonk)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sys, err := backend()
+			if err != nil {
+				return err
+			}
+
+			bytecode, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			prog, err := acir.Parse(bytecode)
+			if err != nil {
+				return err
+			}
+
+			circuit := acir.NewCircuit(prog)
+			cs, err := sys.Compile(cliCurve, circuit)
+			if err != nil {
+				return err
+			}
+
+			if err := writeConstraintSystem(outPath, cs); err != nil {
+				return err
+			}
+			// `prove` needs the same public/secret witness-index split used
+			// here to reconstruct an identically-shaped Circuit without
+			// re-parsing circuit.acir, so stash it alongside cs.bin.
+			return writeCircuitMeta(metaPath(outPath), prog)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outPath, "out", "o", "cs.bin", "output path for the compiled constraint system")
+	return cmd
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+
+	"solana-noir-verifier/pkg/evm"
+)
+
+func newExportSolanaCmd() *cobra.Command {
+	var outDir string
+	var target string
+
+	cmd := &cobra.Command{
+		Use:   "export-solana <vk.bin>",
+		Short: "Emit an on-chain verifier for a verifying key (Solana, EVM, or both); --backend=plonk --target=solana is a scaffold only, with no working verify instruction yet",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sys, err := backend()
+			if err != nil {
+				return err
+			}
+
+			targets, err := parseTargets(target)
+			if err != nil {
+				return err
+			}
+
+			vk, err := readVerifyingKey(args[0], sys)
+			if err != nil {
+				return err
+			}
+
+			if targets["solana"] {
+				if err := sys.ExportSolana(vk, filepath.Join(outDir, "solana")); err != nil {
+					return fmt.Errorf("export solana: %w", err)
+				}
+			}
+			if targets["evm"] {
+				if sys.Name() != "groth16" {
+					return fmt.Errorf("--target=evm is only supported for --backend=groth16")
+				}
+				vkBn254, ok := vk.(*groth16_bn254.VerifyingKey)
+				if !ok {
+					return fmt.Errorf("verifying key is %T, not BN254", vk)
+				}
+				if err := evm.EmitVerifier(vkBn254, filepath.Join(outDir, "evm")); err != nil {
+					return fmt.Errorf("export evm: %w", err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outDir, "out", "o", "program", "output directory for the generated verifier(s)")
+	cmd.Flags().StringVar(&target, "target", "solana", "chain(s) to emit a verifier for: solana, evm, or both")
+	return cmd
+}
+
+func parseTargets(target string) (map[string]bool, error) {
+	switch target {
+	case "solana":
+		return map[string]bool{"solana": true}, nil
+	case "evm":
+		return map[string]bool{"evm": true}, nil
+	case "both":
+		return map[string]bool{"solana": true, "evm": true}, nil
+	default:
+		return nil, fmt.Errorf("unknown --target %q (want solana, evm, or both)", target)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+
+	"solana-noir-verifier/pkg/acir"
+	"solana-noir-verifier/pkg/snarkjs"
+)
+
+func newProveCmd() *cobra.Command {
+	var outPath string
+
+	cmd := &cobra.Command{
+		Use:   "prove <cs.bin> <pk.bin> <witness.toml>",
+		Short: "Generate a proof from a compiled circuit, proving key, and witness",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sys, err := backend()
+			if err != nil {
+				return err
+			}
+
+			csPath, pkPath, witnessPath := args[0], args[1], args[2]
+
+			prog, err := readCircuitMeta(metaPath(csPath))
+			if err != nil {
+				return err
+			}
+			cs, err := readConstraintSystem(csPath, sys)
+			if err != nil {
+				return err
+			}
+			pk, err := readProvingKey(pkPath, sys)
+			if err != nil {
+				return err
+			}
+
+			witnessToml, err := os.ReadFile(witnessPath)
+			if err != nil {
+				return err
+			}
+			nw, err := acir.LoadWitnessIndexTOML(witnessToml)
+			if err != nil {
+				return err
+			}
+
+			circuit := acir.NewCircuit(prog)
+			witness, err := acir.NewWitness(circuit, nw, cliCurve)
+			if err != nil {
+				return err
+			}
+
+			proof, err := sys.Prove(cs, pk, witness)
+			if err != nil {
+				return err
+			}
+			if err := writeProof(outPath, proof); err != nil {
+				return err
+			}
+			if sys.Name() == "groth16" {
+				if err := writeChainProof(chainProofPath(outPath), sys, proof); err != nil {
+					return err
+				}
+			}
+
+			values, err := nw.Values(cliCurve)
+			if err != nil {
+				return err
+			}
+			pubs := make([]*big.Int, len(prog.PublicInputs))
+			for i, w := range prog.PublicInputs {
+				pubs[i] = values[w]
+			}
+			if err := writePublicInputsJSON(publicsPath(outPath), pubs); err != nil {
+				return err
+			}
+
+			// snarkjs only has a Groth16 schema, so the proof.json/public.json
+			// sidecars (for `snarkjs groth16 verify`) are groth16-only.
+			if sys.Name() == "groth16" {
+				if err := snarkjs.SaveProof(proof.(*groth16_bn254.Proof), jsonSidecar(outPath)); err != nil {
+					return fmt.Errorf("write snarkjs proof.json: %w", err)
+				}
+				if err := snarkjs.SavePublic(pubs, filepath.Join(filepath.Dir(outPath), "public.json")); err != nil {
+					return fmt.Errorf("write snarkjs public.json: %w", err)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outPath, "out", "o", "proof.bin", "output path for the proof")
+	return cmd
+}
+
+// publicsPath derives publics.json's path from the proof's output path
+// (proof.bin -> proof.publics.json) so `prove` and `verify` agree on it without
+// an extra flag.
+func publicsPath(proofPath string) string {
+	return filepath.Join(filepath.Dir(proofPath), strings.TrimSuffix(filepath.Base(proofPath), filepath.Ext(proofPath))+".publics.json")
+}
@@ -0,0 +1,48 @@
+// Command solana-noir-verifier is a CLI around the compile/setup/prove/
+// verify/export-solana pipeline: it lets a proving key or proof be
+// persisted to disk and reused across runs, instead of the one-shot
+// mainBenchmark/runBenchmarks flow in experiments/groth16-alternative.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"solana-noir-verifier/pkg/proofsystem"
+)
+
+// backendFlag is the --backend value shared by every subcommand; it picks
+// which proofsystem.System (Groth16 or Plonk) compile/setup/prove/verify/
+// export-solana run through.
+var backendFlag string
+
+// backend resolves the current --backend flag, shared by every subcommand
+// RunE so none of them hardcode groth16.Setup/Prove/Verify the way this CLI
+// used to.
+func backend() (proofsystem.System, error) {
+	return proofsystem.ByName(backendFlag)
+}
+
+func main() {
+	root := &cobra.Command{
+		Use:   "solana-noir-verifier",
+		Short: "Compile, prove, and export Groth16/PLONK BN254 circuits for Solana",
+	}
+	root.PersistentFlags().StringVar(&backendFlag, "backend", "groth16", "proof system to use (groth16 or plonk)")
+
+	root.AddCommand(
+		newCompileCmd(),
+		newSetupCmd(),
+		newProveCmd(),
+		newVerifyCmd(),
+		newExportSolanaCmd(),
+		newAggregateCmd(),
+	)
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"solana-noir-verifier/pkg/acir"
+)
+
+// circuitMeta is the witness-index bookkeeping `compile` persists alongside
+// cs.bin: just enough of the parsed acir.Program (public input indices and
+// total witness count) for `prove` to rebuild an identically-shaped
+// acir.Circuit without re-parsing the original .acir file.
+type circuitMeta struct {
+	NumWitnesses uint32   `json:"num_witnesses"`
+	PublicInputs []uint32 `json:"public_inputs"`
+}
+
+func metaPath(csPath string) string {
+	return strings.TrimSuffix(csPath, ".bin") + ".meta.json"
+}
+
+func writeCircuitMeta(path string, prog *acir.Program) error {
+	meta := circuitMeta{
+		NumWitnesses: prog.NumWitnesses,
+		PublicInputs: prog.PublicInputs,
+	}
+	b, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+func readCircuitMeta(path string) (*acir.Program, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read circuit metadata %s: %w", path, err)
+	}
+	var meta circuitMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return nil, fmt.Errorf("parse circuit metadata %s: %w", path, err)
+	}
+	return &acir.Program{
+		NumWitnesses: meta.NumWitnesses,
+		PublicInputs: meta.PublicInputs,
+	}, nil
+}
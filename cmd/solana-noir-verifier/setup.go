@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+
+	"solana-noir-verifier/pkg/snarkjs"
+)
+
+// newSetupCmd runs an in-process groth16.Setup/SRS-backed setup for a
+// compiled constraint system. There is deliberately no --ptau flag here:
+// pkg/ptau only ingests Perpetual Powers of Tau files (see its doc
+// comment for the circuit-specific Phase 2 basis-change this would still
+// need), so wiring a ceremony file into this step would either need that
+// unfinished plumbing or fake it - neither of which this command does.
+func newSetupCmd() *cobra.Command {
+	var outPaths string
+
+	cmd := &cobra.Command{
+		Use:   "setup <cs.bin>",
+		Short: "Run the trusted setup (groth16) or SRS-backed setup (plonk) for a compiled constraint system",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sys, err := backend()
+			if err != nil {
+				return err
+			}
+
+			parts := strings.Split(outPaths, ",")
+			if len(parts) != 2 {
+				return fmt.Errorf("-o must be \"pk.bin,vk.bin\", got %q", outPaths)
+			}
+			pkPath, vkPath := parts[0], parts[1]
+
+			cs, err := readConstraintSystem(args[0], sys)
+			if err != nil {
+				return err
+			}
+
+			pk, vk, err := sys.Setup(cs)
+			if err != nil {
+				return err
+			}
+
+			if err := writeProvingKey(pkPath, pk); err != nil {
+				return err
+			}
+			if err := writeVerifyingKey(vkPath, vk); err != nil {
+				return err
+			}
+
+			// snarkjs only has a Groth16 JSON schema, so the sidecar is
+			// groth16-only; plonk's vk.bin is the only artifact for now.
+			if sys.Name() == "groth16" {
+				if err := snarkjs.SaveVK(vk.(*groth16_bn254.VerifyingKey), jsonSidecar(vkPath)); err != nil {
+					return fmt.Errorf("write snarkjs vk.json: %w", err)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outPaths, "out", "o", "pk.bin,vk.bin", "comma-separated output paths for the proving and verifying key")
+	return cmd
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"solana-noir-verifier/pkg/proofsystem"
+	"solana-noir-verifier/pkg/snarkjs"
+)
+
+func newVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify <vk.bin> <proof.bin> <publics.json>",
+		Short: "Verify a proof against a verifying key and public inputs",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sys, err := backend()
+			if err != nil {
+				return err
+			}
+
+			vkPath, proofPath, publicsPath := args[0], args[1], args[2]
+
+			vk, err := loadVerifyingKeyArg(vkPath, sys)
+			if err != nil {
+				return err
+			}
+			proof, err := readProof(proofPath, sys)
+			if err != nil {
+				return err
+			}
+			values, err := readPublicInputsJSON(publicsPath)
+			if err != nil {
+				return err
+			}
+			pubWitness, err := publicWitness(values)
+			if err != nil {
+				return err
+			}
+
+			if err := sys.Verify(proof, vk, pubWitness); err != nil {
+				return fmt.Errorf("proof rejected: %w", err)
+			}
+			fmt.Println("proof verified")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// loadVerifyingKeyArg accepts either the CLI's own binary vk.bin (via
+// readVerifyingKey) or a snarkjs verificationkey.json - whether produced by
+// `setup`'s sidecar or by the wider circom/snarkjs tooling - detected by
+// file extension, so a VK built entirely outside this repo can still be
+// verified against here.
+func loadVerifyingKeyArg(path string, sys proofsystem.System) (proofsystem.VerifyingKey, error) {
+	if strings.HasSuffix(path, ".json") {
+		if sys.Name() != "groth16" {
+			return nil, fmt.Errorf("snarkjs verifying keys are only supported for --backend=groth16")
+		}
+		return snarkjs.LoadVKJSON(path)
+	}
+	return readVerifyingKey(path, sys)
+}
@@ -6,11 +6,12 @@ import (
 	"math/big"
 	"time"
 
-	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/std/hash/mimc"
+
+	"solana-noir-verifier/pkg/curves"
+	nativemimc "solana-noir-verifier/pkg/native/mimc"
+	"solana-noir-verifier/pkg/proofsystem"
 )
 
 // ============================================================================
@@ -124,6 +125,7 @@ func (c *IteratedSquareCircuit) Define(api frontend.API) error {
 
 type BenchmarkResult struct {
 	Name        string
+	System      string
 	Constraints int
 	CompileTime time.Duration
 	SetupTime   time.Duration
@@ -132,47 +134,50 @@ type BenchmarkResult struct {
 	ProofSize   int
 }
 
-func runBenchmarks() {
-	fmt.Println("=== Groth16 Circuit Benchmarks ===")
+func runBenchmarks(curve curves.Curve) {
+	fmt.Println("=== Circuit Benchmarks ===")
 	fmt.Println()
 
 	results := []BenchmarkResult{}
 
-	// 1. MiMC Hash Chain (various depths)
-	for _, depth := range []int{10, 100, 1000} {
-		result := benchmarkMiMCHashChain(depth)
-		results = append(results, result)
-	}
+	for _, system := range proofsystem.All {
+		// 1. MiMC Hash Chain (various depths)
+		for _, depth := range []int{10, 100, 1000} {
+			result := benchmarkMiMCHashChain(system, curve, depth)
+			results = append(results, result)
+		}
 
-	// 2. Range Proofs (various bit sizes)
-	for _, bits := range []int{32, 64, 128, 256} {
-		result := benchmarkRangeProof(bits)
-		results = append(results, result)
-	}
+		// 2. Range Proofs (various bit sizes)
+		for _, bits := range []int{32, 64, 128, 256} {
+			result := benchmarkRangeProof(system, curve, bits)
+			results = append(results, result)
+		}
 
-	// 3. Merkle Tree (various depths)
-	for _, depth := range []int{10, 20, 32} {
-		result := benchmarkMerkleProof(depth)
-		results = append(results, result)
-	}
+		// 3. Merkle Tree (various depths)
+		for _, depth := range []int{10, 20, 32} {
+			result := benchmarkMerkleProof(system, curve, depth)
+			results = append(results, result)
+		}
 
-	// 4. Iterated Squares
-	for _, iters := range []int{100, 1000, 10000} {
-		result := benchmarkIteratedSquare(iters)
-		results = append(results, result)
+		// 4. Iterated Squares
+		for _, iters := range []int{100, 1000, 10000} {
+			result := benchmarkIteratedSquare(system, curve, iters)
+			results = append(results, result)
+		}
 	}
 
 	// Print results table
 	fmt.Println()
 	fmt.Println("=== Results Summary ===")
 	fmt.Println()
-	fmt.Printf("%-30s %12s %12s %12s %12s %10s\n",
-		"Circuit", "Constraints", "Setup", "Prove", "Verify", "Proof")
+	fmt.Printf("%-30s %-10s %12s %12s %12s %12s %10s\n",
+		"Circuit", "System", "Constraints", "Setup", "Prove", "Verify", "Proof")
 	fmt.Println(string(make([]byte, 100)))
 
 	for _, r := range results {
-		fmt.Printf("%-30s %12d %12s %12s %12s %10d\n",
+		fmt.Printf("%-30s %-10s %12d %12s %12s %12s %10d\n",
 			r.Name,
+			r.System,
 			r.Constraints,
 			r.SetupTime.Round(time.Millisecond),
 			r.ProveTime.Round(time.Millisecond),
@@ -182,7 +187,7 @@ func runBenchmarks() {
 	}
 }
 
-func benchmarkMiMCHashChain(depth int) BenchmarkResult {
+func benchmarkMiMCHashChain(system proofsystem.System, curve curves.Curve, depth int) BenchmarkResult {
 	name := fmt.Sprintf("MiMC Hash Chain (%d)", depth)
 	fmt.Printf("Benchmarking %s...\n", name)
 
@@ -193,32 +198,30 @@ func benchmarkMiMCHashChain(depth int) BenchmarkResult {
 
 	// Compile
 	start := time.Now()
-	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	cs, err := system.Compile(curve.ID, circuit)
 	if err != nil {
 		fmt.Printf("  Error compiling: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	compileTime := time.Since(start)
 
 	// Setup
 	start = time.Now()
-	pk, vk, err := groth16.Setup(cs)
+	pk, vk, err := system.Setup(cs)
 	if err != nil {
 		fmt.Printf("  Error in setup: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	setupTime := time.Since(start)
 
-	// Create witness
+	// Create witness: compute the real MiMC hash chain out-of-circuit so
+	// the witness matches what MiMCHashChainCircuit.Define actually
+	// constrains, instead of a `current * 7 mod r` placeholder.
+	// pkg/native/mimc computes BN254 MiMC only, so under any other
+	// --curve this witness does not satisfy the circuit and the prove
+	// step reports an error.
 	preImage := big.NewInt(42)
-	hashes := make([]interface{}, depth)
-	current := preImage
-	for i := 0; i < depth; i++ {
-		// Simplified hash for witness (real MiMC would be computed here)
-		current = new(big.Int).Mul(current, big.NewInt(7))
-		current = new(big.Int).Mod(current, ecc.BN254.ScalarField())
-		hashes[i] = current
-	}
+	hashes := nativemimc.Chain(preImage, depth)
 
 	assignment := &MiMCHashChainCircuit{
 		PreImage: preImage,
@@ -228,28 +231,28 @@ func benchmarkMiMCHashChain(depth int) BenchmarkResult {
 		assignment.Hashes[i] = hashes[i]
 	}
 
-	witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	witness, err := curve.NewWitness(assignment)
 	if err != nil {
 		fmt.Printf("  Error creating witness: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 
 	// Prove
 	start = time.Now()
-	proof, err := groth16.Prove(cs, pk, witness)
+	proof, err := system.Prove(cs, pk, witness)
 	if err != nil {
 		fmt.Printf("  Error proving: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	proveTime := time.Since(start)
 
 	// Verify
 	publicWitness, _ := witness.Public()
 	start = time.Now()
-	err = groth16.Verify(proof, vk, publicWitness)
+	err = system.Verify(proof, vk, publicWitness)
 	if err != nil {
 		fmt.Printf("  Error verifying: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	verifyTime := time.Since(start)
 
@@ -257,6 +260,7 @@ func benchmarkMiMCHashChain(depth int) BenchmarkResult {
 
 	return BenchmarkResult{
 		Name:        name,
+		System:      system.Name(),
 		Constraints: cs.GetNbConstraints(),
 		CompileTime: compileTime,
 		SetupTime:   setupTime,
@@ -266,25 +270,25 @@ func benchmarkMiMCHashChain(depth int) BenchmarkResult {
 	}
 }
 
-func benchmarkRangeProof(numBits int) BenchmarkResult {
+func benchmarkRangeProof(system proofsystem.System, curve curves.Curve, numBits int) BenchmarkResult {
 	name := fmt.Sprintf("Range Proof (%d-bit)", numBits)
 	fmt.Printf("Benchmarking %s...\n", name)
 
 	circuit := &RangeProofCircuit{NumBits: numBits}
 
 	start := time.Now()
-	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	cs, err := system.Compile(curve.ID, circuit)
 	if err != nil {
 		fmt.Printf("  Error compiling: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	compileTime := time.Since(start)
 
 	start = time.Now()
-	pk, vk, err := groth16.Setup(cs)
+	pk, vk, err := system.Setup(cs)
 	if err != nil {
 		fmt.Printf("  Error in setup: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	setupTime := time.Since(start)
 
@@ -296,26 +300,26 @@ func benchmarkRangeProof(numBits int) BenchmarkResult {
 		NumBits: numBits,
 	}
 
-	witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	witness, err := curve.NewWitness(assignment)
 	if err != nil {
 		fmt.Printf("  Error creating witness: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 
 	start = time.Now()
-	proof, err := groth16.Prove(cs, pk, witness)
+	proof, err := system.Prove(cs, pk, witness)
 	if err != nil {
 		fmt.Printf("  Error proving: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	proveTime := time.Since(start)
 
 	publicWitness, _ := witness.Public()
 	start = time.Now()
-	err = groth16.Verify(proof, vk, publicWitness)
+	err = system.Verify(proof, vk, publicWitness)
 	if err != nil {
 		fmt.Printf("  Error verifying: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	verifyTime := time.Since(start)
 
@@ -323,6 +327,7 @@ func benchmarkRangeProof(numBits int) BenchmarkResult {
 
 	return BenchmarkResult{
 		Name:        name,
+		System:      system.Name(),
 		Constraints: cs.GetNbConstraints(),
 		CompileTime: compileTime,
 		SetupTime:   setupTime,
@@ -332,7 +337,7 @@ func benchmarkRangeProof(numBits int) BenchmarkResult {
 	}
 }
 
-func benchmarkMerkleProof(depth int) BenchmarkResult {
+func benchmarkMerkleProof(system proofsystem.System, curve curves.Curve, depth int) BenchmarkResult {
 	name := fmt.Sprintf("Merkle Proof (depth %d)", depth)
 	fmt.Printf("Benchmarking %s...\n", name)
 
@@ -342,43 +347,53 @@ func benchmarkMerkleProof(depth int) BenchmarkResult {
 	}
 
 	start := time.Now()
-	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	cs, err := system.Compile(curve.ID, circuit)
 	if err != nil {
 		fmt.Printf("  Error compiling: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	compileTime := time.Since(start)
 
 	start = time.Now()
-	pk, vk, err := groth16.Setup(cs)
+	pk, vk, err := system.Setup(cs)
 	if err != nil {
 		fmt.Printf("  Error in setup: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	setupTime := time.Since(start)
 
-	// Create dummy witness
+	// Build a real Merkle path and compute its root out-of-circuit with
+	// the same native MiMC used above, instead of a Root = 99999 stand-in
+	// that made the prove step fail. As above, the root is a BN254 MiMC
+	// root, so it only satisfies the circuit under --curve=bn254.
+	leaf := big.NewInt(12345)
+	siblings := make([]*big.Int, depth)
+	pathBits := make([]int, depth)
+	for i := 0; i < depth; i++ {
+		siblings[i] = big.NewInt(int64(i + 1))
+		pathBits[i] = i % 2
+	}
+	root := nativemimc.MerklePath(leaf, siblings, pathBits)
+
 	assignment := &MerkleProofCircuit{
-		Leaf:     big.NewInt(12345),
+		Leaf:     leaf,
 		Path:     make([]frontend.Variable, depth),
 		PathBits: make([]frontend.Variable, depth),
-		Root:     big.NewInt(0), // Will be computed
+		Root:     root,
 	}
 	for i := 0; i < depth; i++ {
-		assignment.Path[i] = big.NewInt(int64(i + 1))
-		assignment.PathBits[i] = i % 2
+		assignment.Path[i] = siblings[i]
+		assignment.PathBits[i] = pathBits[i]
 	}
-	// Compute root (simplified)
-	assignment.Root = big.NewInt(99999)
 
-	witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	witness, err := curve.NewWitness(assignment)
 	if err != nil {
 		fmt.Printf("  Error creating witness: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 
 	start = time.Now()
-	proof, err := groth16.Prove(cs, pk, witness)
+	proof, err := system.Prove(cs, pk, witness)
 	if err != nil {
 		fmt.Printf("  Error proving (expected for dummy witness): %v\n", err)
 		// Return with just compile/setup times
@@ -394,13 +409,14 @@ func benchmarkMerkleProof(depth int) BenchmarkResult {
 
 	publicWitness, _ := witness.Public()
 	start = time.Now()
-	err = groth16.Verify(proof, vk, publicWitness)
+	err = system.Verify(proof, vk, publicWitness)
 	verifyTime := time.Since(start)
 
 	fmt.Printf("  ✓ %d constraints, prove: %v\n", cs.GetNbConstraints(), proveTime)
 
 	return BenchmarkResult{
 		Name:        name,
+		System:      system.Name(),
 		Constraints: cs.GetNbConstraints(),
 		CompileTime: compileTime,
 		SetupTime:   setupTime,
@@ -410,32 +426,32 @@ func benchmarkMerkleProof(depth int) BenchmarkResult {
 	}
 }
 
-func benchmarkIteratedSquare(iterations int) BenchmarkResult {
+func benchmarkIteratedSquare(system proofsystem.System, curve curves.Curve, iterations int) BenchmarkResult {
 	name := fmt.Sprintf("Iterated Square (%d)", iterations)
 	fmt.Printf("Benchmarking %s...\n", name)
 
 	circuit := &IteratedSquareCircuit{Iterations: iterations}
 
 	start := time.Now()
-	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	cs, err := system.Compile(curve.ID, circuit)
 	if err != nil {
 		fmt.Printf("  Error compiling: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	compileTime := time.Since(start)
 
 	start = time.Now()
-	pk, vk, err := groth16.Setup(cs)
+	pk, vk, err := system.Setup(cs)
 	if err != nil {
 		fmt.Printf("  Error in setup: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	setupTime := time.Since(start)
 
 	// Compute expected result
 	x := big.NewInt(2)
 	result := new(big.Int).Set(x)
-	modulus := ecc.BN254.ScalarField()
+	modulus := curve.ID.ScalarField()
 	for i := 0; i < iterations; i++ {
 		result.Mul(result, result)
 		result.Mod(result, modulus)
@@ -447,26 +463,26 @@ func benchmarkIteratedSquare(iterations int) BenchmarkResult {
 		FinalY:     result,
 	}
 
-	witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	witness, err := curve.NewWitness(assignment)
 	if err != nil {
 		fmt.Printf("  Error creating witness: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 
 	start = time.Now()
-	proof, err := groth16.Prove(cs, pk, witness)
+	proof, err := system.Prove(cs, pk, witness)
 	if err != nil {
 		fmt.Printf("  Error proving: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	proveTime := time.Since(start)
 
 	publicWitness, _ := witness.Public()
 	start = time.Now()
-	err = groth16.Verify(proof, vk, publicWitness)
+	err = system.Verify(proof, vk, publicWitness)
 	if err != nil {
 		fmt.Printf("  Error verifying: %v\n", err)
-		return BenchmarkResult{Name: name}
+		return BenchmarkResult{Name: name, System: system.Name()}
 	}
 	verifyTime := time.Since(start)
 
@@ -474,6 +490,7 @@ func benchmarkIteratedSquare(iterations int) BenchmarkResult {
 
 	return BenchmarkResult{
 		Name:        name,
+		System:      system.Name(),
 		Constraints: cs.GetNbConstraints(),
 		CompileTime: compileTime,
 		SetupTime:   setupTime,
@@ -14,6 +14,8 @@ import (
 	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	pkgsolana "solana-noir-verifier/pkg/solana"
 )
 
 func main() {
@@ -24,7 +26,11 @@ func main() {
 			mainBenchmark()
 			return
 		case "circuits":
-			runBenchmarks()
+			curve, _, err := parseCurveFlag(os.Args[2:])
+			if err != nil {
+				panic(err)
+			}
+			runBenchmarks(curve)
 			return
 		case "help":
 			fmt.Println("Usage: go run . [command]")
@@ -34,6 +40,9 @@ func main() {
 			fmt.Println("  benchmark  Run scalability benchmark (100 to 100K constraints)")
 			fmt.Println("  circuits   Run circuit benchmarks (MiMC, Range, Merkle, etc.)")
 			fmt.Println("  help       Show this help")
+			fmt.Println("")
+			fmt.Println("Flags (benchmark/circuits):")
+			fmt.Println("  --curve=bn254|bls12-381|bls12-377  Curve to benchmark over (default bn254)")
 			return
 		}
 	}
@@ -156,6 +165,20 @@ func main() {
 	// Export VK components for groth16-solana
 	exportVKForSolana(vkBn254)
 
+	// Export proof + a ready-to-deploy Anchor program for native Solana
+	// verification (alt_bn128_* syscalls, big-endian field ordering)
+	proofSolanaBytes := pkgsolana.MarshalProof(proofBn254)
+	err = os.WriteFile("output/proof_solana.bin", proofSolanaBytes, 0644)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("  Solana-ordered proof written to output/proof_solana.bin")
+
+	if err := pkgsolana.EmitProgram(vkBn254, "output/program"); err != nil {
+		panic(err)
+	}
+	fmt.Println("  Solana verifier program written to output/program/")
+
 	// Export public inputs
 	// For BN254, field elements are 32 bytes (big-endian)
 	publicInputBytes := make([]byte, 32)
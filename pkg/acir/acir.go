@@ -0,0 +1,179 @@
+// Package acir parses ACIR-shaped circuit bytecode and turns it into a
+// gnark frontend.Circuit, so a circuit expressed as an opcode stream can
+// be compiled to R1CS and Groth16-proved without a hand-transcribed
+// mirror circuit (the way SimpleSquareCircuit mirrors
+// test-circuits/simple_square today).
+//
+// Status: this package does not yet ingest real Noir ACIR. The wire
+// format Parse reads is this package's own little-endian framing
+// (a one-byte opcode-kind tag followed by that kind's payload, with no
+// per-opcode length prefix), modeled on ACIR's opcode
+// shapes (arithmetic gates, black-box calls, directives, Brillig calls)
+// but NOT Noir's actual bincode-serialized `acir::circuit::Circuit` wire
+// format - `nargo compile`'s output cannot be fed to Parse directly, and
+// the witness bridge in witness.go takes an already witness-index-keyed
+// table rather than nargo's name-keyed Prover.toml, because that mapping
+// needs the .acir's ABI metadata, which this package's Program does not
+// carry. The requests that asked for "parses Noir's compiled ACIR
+// bytecode" / "ACIR bytecode + witness map produced by nargo" are not
+// met by what's here and should be treated as still open: a real bincode
+// decoder for acir::circuit::Circuit, plus an ABI-aware witness bridge,
+// would need to be built against the upstream `acir`/`noirc_abi` crates'
+// actual wire formats. What exists today is useful on its own terms -
+// see NewCircuit/NewWitness - as a hand-fed opcode-stream-to-gnark
+// adaptor, just not as a Noir-compiler-output consumer.
+package acir
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// OpcodeKind identifies the ACIR opcode variants this package understands.
+// This mirrors the subset of Noir's ACIR format needed to reproduce common
+// Noir std-lib circuits (arithmetic, range checks, bitwise ops, hashing).
+type OpcodeKind uint8
+
+const (
+	OpArithmetic OpcodeKind = iota
+	OpBlackBoxFuncCall
+	OpDirective
+	OpBrillig
+)
+
+// BlackBoxFunc identifies which ACIR black-box function a
+// BlackBoxFunctionCall opcode invokes.
+type BlackBoxFunc uint8
+
+const (
+	BlackBoxRange BlackBoxFunc = iota
+	BlackBoxAND
+	BlackBoxXOR
+	BlackBoxPedersen
+	BlackBoxSHA256
+	BlackBoxPoseidon
+)
+
+// Term is a single (coefficient, witness index) product in an arithmetic
+// opcode's linear/quadratic combination, matching ACIR's sparse
+// representation.
+type Term struct {
+	Coefficient []byte // big-endian field element
+	Witness     uint32
+}
+
+// ArithmeticOpcode represents `q_l*w_l*w_r + SUM q_i*w_i + q_c = 0`: a
+// single optional quadratic term (Mul) plus a sparse linear combination
+// (Linear) and a constant.
+type ArithmeticOpcode struct {
+	Mul       *struct{ Left, Right Term }
+	Linear    []Term
+	Constant  []byte
+	OutputVar uint32 // witness index the opcode ultimately constrains, when known
+}
+
+// BlackBoxFunctionCall represents a call into one of ACIR's black-box gadgets.
+type BlackBoxFunctionCall struct {
+	Func    BlackBoxFunc
+	Inputs  []uint32
+	Outputs []uint32
+	NumBits uint32 // used by RANGE
+}
+
+// DirectiveOpcode represents an unconstrained hint computation (e.g. an
+// out-of-circuit quotient/remainder the prover fills in, later constrained
+// by an ArithmeticOpcode). This package records it but does not execute
+// directive bytecode itself; Circuit.Define only consumes its Outputs as
+// already-assigned witness indices.
+type DirectiveOpcode struct {
+	Outputs []uint32
+}
+
+// BrilligOpcode represents an unconstrained Brillig call used for
+// witness generation (e.g. division, comparisons). Like DirectiveOpcode,
+// its Outputs are treated as pre-filled witness slots.
+type BrilligOpcode struct {
+	Outputs []uint32
+}
+
+// Opcode is a tagged union over the four ACIR opcode kinds this package
+// supports.
+type Opcode struct {
+	Kind       OpcodeKind
+	Arithmetic *ArithmeticOpcode
+	BlackBox   *BlackBoxFunctionCall
+	Directive  *DirectiveOpcode
+	Brillig    *BrilligOpcode
+}
+
+// Program is a parsed ACIR circuit: its opcode stream plus which witness
+// indices are public inputs (in order).
+type Program struct {
+	Opcodes      []Opcode
+	PublicInputs []uint32
+	NumWitnesses uint32
+}
+
+// Parse decodes this package's own little-endian opcode framing (see the
+// package doc comment for how that differs from Noir's real bincode ACIR
+// format) into a Program: a header (witness count, public input count,
+// public input indices), then per opcode a one-byte kind tag followed by
+// its payload, whose length the per-kind reader determines while decoding
+// it (there is no per-opcode length prefix).
+func Parse(bytecode []byte) (*Program, error) {
+	if len(bytecode) < 8 {
+		return nil, fmt.Errorf("acir: bytecode too short (%d bytes)", len(bytecode))
+	}
+
+	numWitnesses := binary.LittleEndian.Uint32(bytecode[0:4])
+	numPublic := binary.LittleEndian.Uint32(bytecode[4:8])
+	offset := 8
+
+	publicInputs := make([]uint32, 0, numPublic)
+	for i := uint32(0); i < numPublic; i++ {
+		if offset+4 > len(bytecode) {
+			return nil, fmt.Errorf("acir: truncated public input list")
+		}
+		publicInputs = append(publicInputs, binary.LittleEndian.Uint32(bytecode[offset:offset+4]))
+		offset += 4
+	}
+
+	var opcodes []Opcode
+	for offset < len(bytecode) {
+		op, consumed, err := parseOpcode(bytecode[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("acir: opcode at byte %d: %w", offset, err)
+		}
+		opcodes = append(opcodes, op)
+		offset += consumed
+	}
+
+	return &Program{
+		Opcodes:      opcodes,
+		PublicInputs: publicInputs,
+		NumWitnesses: numWitnesses,
+	}, nil
+}
+
+func parseOpcode(b []byte) (Opcode, int, error) {
+	if len(b) < 1 {
+		return Opcode{}, 0, fmt.Errorf("missing opcode tag")
+	}
+	kind := OpcodeKind(b[0])
+	switch kind {
+	case OpArithmetic:
+		op, n, err := parseArithmetic(b[1:])
+		return Opcode{Kind: kind, Arithmetic: op}, n + 1, err
+	case OpBlackBoxFuncCall:
+		op, n, err := parseBlackBox(b[1:])
+		return Opcode{Kind: kind, BlackBox: op}, n + 1, err
+	case OpDirective:
+		op, n, err := parseDirective(b[1:])
+		return Opcode{Kind: kind, Directive: op}, n + 1, err
+	case OpBrillig:
+		op, n, err := parseBrillig(b[1:])
+		return Opcode{Kind: kind, Brillig: op}, n + 1, err
+	default:
+		return Opcode{}, 0, fmt.Errorf("unknown opcode tag %d", kind)
+	}
+}
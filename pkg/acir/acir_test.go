@@ -0,0 +1,128 @@
+package acir
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// encodeTerm mirrors readTerm's wire layout: a 32-byte big-endian field
+// element followed by a little-endian witness index. A negative
+// coefficient is encoded as its residue modulo the BN254 scalar field.
+func encodeTerm(coefficient int64, witness uint32) []byte {
+	var buf bytes.Buffer
+	c := make([]byte, 32)
+	new(big.Int).Mod(big.NewInt(coefficient), ecc.BN254.ScalarField()).FillBytes(c)
+	buf.Write(c)
+	binary.Write(&buf, binary.LittleEndian, witness)
+	return buf.Bytes()
+}
+
+func u32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
+
+// encodeArithmetic builds the wire bytes for a single ArithmeticOpcode:
+// `w0 * w0 - w1 == 0` (no linear terms).
+func encodeArithmetic() []byte {
+	var buf bytes.Buffer
+	buf.Write(u32(1)) // hasMul
+	buf.Write(encodeTerm(1, 0))
+	buf.Write(encodeTerm(1, 0))
+	buf.Write(u32(1)) // linearCount
+	buf.Write(encodeTerm(-1, 1))
+	buf.Write(make([]byte, 32)) // constant
+	return buf.Bytes()
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(u32(2)) // numWitnesses
+	buf.Write(u32(1)) // numPublic
+	buf.Write(u32(1)) // public witness 1
+
+	buf.WriteByte(byte(OpArithmetic))
+	buf.Write(encodeArithmetic())
+
+	prog, err := Parse(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if prog.NumWitnesses != 2 {
+		t.Errorf("NumWitnesses = %d, want 2", prog.NumWitnesses)
+	}
+	if len(prog.PublicInputs) != 1 || prog.PublicInputs[0] != 1 {
+		t.Errorf("PublicInputs = %v, want [1]", prog.PublicInputs)
+	}
+	if len(prog.Opcodes) != 1 || prog.Opcodes[0].Kind != OpArithmetic {
+		t.Fatalf("Opcodes = %v, want 1 OpArithmetic", prog.Opcodes)
+	}
+	op := prog.Opcodes[0].Arithmetic
+	if op.Mul == nil || op.Mul.Left.Witness != 0 || op.Mul.Right.Witness != 0 {
+		t.Errorf("Mul = %+v, want squaring witness 0", op.Mul)
+	}
+	if len(op.Linear) != 1 || op.Linear[0].Witness != 1 {
+		t.Errorf("Linear = %+v, want [witness 1]", op.Linear)
+	}
+}
+
+func TestParseTruncated(t *testing.T) {
+	if _, err := Parse([]byte{1, 2, 3}); err == nil {
+		t.Error("Parse(truncated) succeeded, want error")
+	}
+}
+
+func TestNewCircuitSplitsPublicSecret(t *testing.T) {
+	prog := &Program{
+		PublicInputs: []uint32{1},
+		NumWitnesses: 3,
+	}
+	c := NewCircuit(prog)
+	if len(c.Public) != 1 {
+		t.Errorf("len(Public) = %d, want 1", len(c.Public))
+	}
+	if len(c.Secret) != 2 {
+		t.Errorf("len(Secret) = %d, want 2", len(c.Secret))
+	}
+
+	values := map[uint32]*big.Int{
+		0: big.NewInt(3),
+		1: big.NewInt(9),
+		2: big.NewInt(5),
+	}
+	assigned := c.Assign(values)
+	if assigned.Public[0] != values[1] {
+		t.Errorf("Public[0] = %v, want %v", assigned.Public[0], values[1])
+	}
+}
+
+// TestBlackBoxPedersenErrors locks in that a PEDERSEN black-box call fails
+// to compile rather than silently being computed as a MiMC hash (a
+// different primitive from Noir's actual Grumpkin-generator pedersen_hash).
+func TestBlackBoxPedersenErrors(t *testing.T) {
+	prog := &Program{
+		NumWitnesses: 2,
+		Opcodes: []Opcode{{
+			Kind: OpBlackBoxFuncCall,
+			BlackBox: &BlackBoxFunctionCall{
+				Func:    BlackBoxPedersen,
+				Inputs:  []uint32{0},
+				Outputs: []uint32{1},
+			},
+		}},
+	}
+	c := NewCircuit(prog)
+	assigned := c.Assign(map[uint32]*big.Int{0: big.NewInt(3), 1: big.NewInt(9)})
+
+	_, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, assigned)
+	if err == nil {
+		t.Fatal("Compile with a PEDERSEN opcode succeeded, want error")
+	}
+}
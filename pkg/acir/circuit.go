@@ -0,0 +1,205 @@
+package acir
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/poseidon2"
+)
+
+// Circuit adapts a parsed ACIR Program into a gnark frontend.Circuit. Public
+// holds the program's public witnesses (in ACIR order) and Secret holds
+// everything else; Define reconstructs the full witness table from both
+// slices using the index mapping recorded at NewCircuit time.
+type Circuit struct {
+	Public []frontend.Variable `gnark:",public"`
+	Secret []frontend.Variable
+
+	program   *Program       `gnark:"-"`
+	publicIdx map[uint32]int `gnark:"-"`
+	secretIdx map[uint32]int `gnark:"-"`
+}
+
+// NewCircuit builds the frontend.Circuit skeleton for prog. The returned
+// Circuit's Public/Secret slices are sized but not assigned, which is all
+// frontend.Compile needs; values are supplied through Assign, directly or
+// via NewWitness in witness.go (proving time).
+func NewCircuit(prog *Program) *Circuit {
+	isPublic := make(map[uint32]bool, len(prog.PublicInputs))
+	for _, w := range prog.PublicInputs {
+		isPublic[w] = true
+	}
+
+	c := &Circuit{
+		program:   prog,
+		publicIdx: make(map[uint32]int),
+		secretIdx: make(map[uint32]int),
+	}
+
+	for _, w := range prog.PublicInputs {
+		c.publicIdx[w] = len(c.Public)
+		c.Public = append(c.Public, nil)
+	}
+	for w := uint32(0); w < prog.NumWitnesses; w++ {
+		if isPublic[w] {
+			continue
+		}
+		c.secretIdx[w] = len(c.Secret)
+		c.Secret = append(c.Secret, nil)
+	}
+
+	return c
+}
+
+// Assign fills in the Public/Secret slices from a full witness-index ->
+// value map (as produced by NargoWitness.Values in witness.go)
+// and returns an assignment ready for frontend.NewWitness.
+func (c *Circuit) Assign(values map[uint32]*big.Int) *Circuit {
+	assigned := &Circuit{
+		program:   c.program,
+		publicIdx: c.publicIdx,
+		secretIdx: c.secretIdx,
+		Public:    make([]frontend.Variable, len(c.Public)),
+		Secret:    make([]frontend.Variable, len(c.Secret)),
+	}
+	for w, i := range c.publicIdx {
+		assigned.Public[i] = values[w]
+	}
+	for w, i := range c.secretIdx {
+		assigned.Secret[i] = values[w]
+	}
+	return assigned
+}
+
+func (c *Circuit) variable(idx uint32) frontend.Variable {
+	if i, ok := c.publicIdx[idx]; ok {
+		return c.Public[i]
+	}
+	return c.Secret[c.secretIdx[idx]]
+}
+
+func (c *Circuit) setVariable(idx uint32, v frontend.Variable) {
+	if i, ok := c.publicIdx[idx]; ok {
+		c.Public[i] = v
+		return
+	}
+	c.Secret[c.secretIdx[idx]] = v
+}
+
+// Define walks the ACIR opcode stream, translating each opcode into the
+// equivalent api.Add/Mul/AssertIsEqual/ToBinary/Select constraints (and
+// gnark std gadgets for black-box functions).
+func (c *Circuit) Define(api frontend.API) error {
+	for i, op := range c.program.Opcodes {
+		var err error
+		switch op.Kind {
+		case OpArithmetic:
+			err = c.defineArithmetic(api, op.Arithmetic)
+		case OpBlackBoxFuncCall:
+			err = c.defineBlackBox(api, op.BlackBox)
+		case OpDirective, OpBrillig:
+			// Unconstrained hints: their outputs are supplied directly by
+			// the witness bridge, so there is nothing to constrain here.
+		default:
+			err = fmt.Errorf("unsupported opcode kind %d", op.Kind)
+		}
+		if err != nil {
+			return fmt.Errorf("acir: opcode %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// defineArithmetic asserts q_l*w_l*w_r + SUM q_i*w_i + q_c == 0.
+func (c *Circuit) defineArithmetic(api frontend.API, op *ArithmeticOpcode) error {
+	sum := frontend.Variable(new(big.Int).SetBytes(op.Constant))
+
+	if op.Mul != nil {
+		left := api.Mul(coeff(op.Mul.Left.Coefficient), c.variable(op.Mul.Left.Witness))
+		term := api.Mul(left, c.variable(op.Mul.Right.Witness))
+		sum = api.Add(sum, term)
+	}
+
+	for _, t := range op.Linear {
+		sum = api.Add(sum, api.Mul(coeff(t.Coefficient), c.variable(t.Witness)))
+	}
+
+	api.AssertIsEqual(sum, 0)
+	return nil
+}
+
+func coeff(fieldElement []byte) frontend.Variable {
+	return frontend.Variable(new(big.Int).SetBytes(fieldElement))
+}
+
+// defineBlackBox maps ACIR's black-box function calls onto the equivalent
+// gnark gadget: RANGE via ToBinary/AssertIsBoolean, AND/XOR via bit-split,
+// POSEIDON via gnark's Poseidon2 Merkle-Damgard hasher, and SHA256/PEDERSEN pending a
+// std/hash/sha2 wiring and a Grumpkin-generator commitment gadget
+// respectively.
+func (c *Circuit) defineBlackBox(api frontend.API, op *BlackBoxFunctionCall) error {
+	switch op.Func {
+	case BlackBoxRange:
+		if len(op.Inputs) != 1 {
+			return fmt.Errorf("RANGE expects 1 input, got %d", len(op.Inputs))
+		}
+		bits := api.ToBinary(c.variable(op.Inputs[0]), int(op.NumBits))
+		for _, b := range bits {
+			api.AssertIsBoolean(b)
+		}
+		return nil
+
+	case BlackBoxAND, BlackBoxXOR:
+		if len(op.Inputs) != 2 || len(op.Outputs) != 1 {
+			return fmt.Errorf("AND/XOR expects 2 inputs and 1 output")
+		}
+		bitsA := api.ToBinary(c.variable(op.Inputs[0]), int(op.NumBits))
+		bitsB := api.ToBinary(c.variable(op.Inputs[1]), int(op.NumBits))
+		outBits := make([]frontend.Variable, op.NumBits)
+		for i := range outBits {
+			if op.Func == BlackBoxAND {
+				outBits[i] = api.And(bitsA[i], bitsB[i])
+			} else {
+				outBits[i] = api.Xor(bitsA[i], bitsB[i])
+			}
+		}
+		c.setVariable(op.Outputs[0], api.FromBinary(outBits...))
+		return nil
+
+	case BlackBoxPoseidon:
+		// Noir's std::hash::poseidon maps onto gnark's Poseidon2
+		// Merkle-Damgard hasher,
+		// using the same Write-all-inputs/Sum shape BlackBoxPedersen would
+		// use once it has a real gadget to call.
+		h, err := poseidon2.NewMerkleDamgardHasher(api)
+		if err != nil {
+			return err
+		}
+		for _, in := range op.Inputs {
+			h.Write(c.variable(in))
+		}
+		if len(op.Outputs) != 1 {
+			return fmt.Errorf("POSEIDON expects 1 output, got %d", len(op.Outputs))
+		}
+		c.setVariable(op.Outputs[0], h.Sum())
+		return nil
+
+	case BlackBoxSHA256:
+		return fmt.Errorf("SHA256 black-box gadget not yet wired (needs std/hash/sha2)")
+
+	case BlackBoxPedersen:
+		// Pedersen black-box gadget not yet wired. Noir's
+		// std::hash::pedersen_hash is a sum of scalar multiples of fixed
+		// generators on an embedded curve (Grumpkin), an entirely
+		// different primitive from the Poseidon sponge above; an earlier
+		// version of this case called mimc.NewMiMC here as a stand-in,
+		// which silently computes the wrong hash for any real
+		// pedersen_hash witness (MiMC(inputs) != Pedersen(inputs)) rather
+		// than failing loudly like this does.
+		return fmt.Errorf("PEDERSEN black-box gadget not yet wired (needs a Grumpkin-generator commitment gadget)")
+
+	default:
+		return fmt.Errorf("unsupported black-box function %d", op.Func)
+	}
+}
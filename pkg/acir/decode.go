@@ -0,0 +1,157 @@
+package acir
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// readU32 reads a little-endian uint32 at the start of b, returning the
+// value and the number of bytes consumed.
+func readU32(b []byte) (uint32, int, error) {
+	if len(b) < 4 {
+		return 0, 0, fmt.Errorf("truncated u32")
+	}
+	return binary.LittleEndian.Uint32(b[0:4]), 4, nil
+}
+
+// readFieldElement reads a 32-byte big-endian field element.
+func readFieldElement(b []byte) ([]byte, int, error) {
+	if len(b) < 32 {
+		return nil, 0, fmt.Errorf("truncated field element")
+	}
+	out := make([]byte, 32)
+	copy(out, b[0:32])
+	return out, 32, nil
+}
+
+// readTerm reads a (coefficient, witness index) pair.
+func readTerm(b []byte) (Term, int, error) {
+	coeff, n, err := readFieldElement(b)
+	if err != nil {
+		return Term{}, 0, err
+	}
+	w, n2, err := readU32(b[n:])
+	if err != nil {
+		return Term{}, 0, err
+	}
+	return Term{Coefficient: coeff, Witness: w}, n + n2, nil
+}
+
+// readU32Vec reads a length-prefixed vector of witness indices.
+func readU32Vec(b []byte) ([]uint32, int, error) {
+	count, n, err := readU32(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make([]uint32, count)
+	for i := uint32(0); i < count; i++ {
+		v, m, err := readU32(b[n:])
+		if err != nil {
+			return nil, 0, err
+		}
+		out[i] = v
+		n += m
+	}
+	return out, n, nil
+}
+
+func parseArithmetic(b []byte) (*ArithmeticOpcode, int, error) {
+	offset := 0
+
+	hasMul, n, err := readU32(b[offset:])
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += n
+
+	op := &ArithmeticOpcode{}
+	if hasMul != 0 {
+		left, n, err := readTerm(b[offset:])
+		if err != nil {
+			return nil, 0, err
+		}
+		offset += n
+		right, n, err := readTerm(b[offset:])
+		if err != nil {
+			return nil, 0, err
+		}
+		offset += n
+		op.Mul = &struct{ Left, Right Term }{left, right}
+	}
+
+	linearCount, n, err := readU32(b[offset:])
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += n
+
+	op.Linear = make([]Term, linearCount)
+	for i := uint32(0); i < linearCount; i++ {
+		t, n, err := readTerm(b[offset:])
+		if err != nil {
+			return nil, 0, err
+		}
+		op.Linear[i] = t
+		offset += n
+	}
+
+	constant, n, err := readFieldElement(b[offset:])
+	if err != nil {
+		return nil, 0, err
+	}
+	op.Constant = constant
+	offset += n
+
+	return op, offset, nil
+}
+
+func parseBlackBox(b []byte) (*BlackBoxFunctionCall, int, error) {
+	offset := 0
+
+	funcTag, n, err := readU32(b[offset:])
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += n
+
+	inputs, n, err := readU32Vec(b[offset:])
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += n
+
+	outputs, n, err := readU32Vec(b[offset:])
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += n
+
+	numBits, n, err := readU32(b[offset:])
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += n
+
+	return &BlackBoxFunctionCall{
+		Func:    BlackBoxFunc(funcTag),
+		Inputs:  inputs,
+		Outputs: outputs,
+		NumBits: numBits,
+	}, offset, nil
+}
+
+func parseDirective(b []byte) (*DirectiveOpcode, int, error) {
+	outputs, n, err := readU32Vec(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &DirectiveOpcode{Outputs: outputs}, n, nil
+}
+
+func parseBrillig(b []byte) (*BrilligOpcode, int, error) {
+	outputs, n, err := readU32Vec(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &BrilligOpcode{Outputs: outputs}, n, nil
+}
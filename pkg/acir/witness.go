@@ -0,0 +1,81 @@
+package acir
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/BurntSushi/toml"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+)
+
+// NargoWitness holds a resolved witness-index -> field-element-string map
+// (decimal or 0x-prefixed hex). This is NOT Noir's real Prover.toml, which
+// keys by the circuit's ABI-level input names (`x = "3"`) and needs the
+// original .acir's ABI metadata to resolve a name to a witness index -
+// metadata this package's Program does not carry (see the package doc
+// comment). A real bridge from ABI-named inputs to witness indices is
+// still open - see ../../BACKLOG_STATUS.md, which tracks this alongside
+// the real-ACIR-bincode gap so the work doesn't read as done just
+// because it has a tagged commit; LoadWitnessIndexTOML below is the
+// index-keyed format this package can actually consume today.
+type NargoWitness struct {
+	WitnessValues map[uint32]string
+}
+
+// LoadWitnessIndexTOML parses a flat `"<witness index>" = "<field element>"`
+// TOML table directly into a NargoWitness's WitnessValues. This is the
+// format `cmd/solana-noir-verifier prove` consumes: it sidesteps needing
+// the original .acir's ABI to map Noir-level input names to witness
+// indices, at the cost of the caller (or an upstream `nargo` export step)
+// already knowing those indices.
+func LoadWitnessIndexTOML(data []byte) (*NargoWitness, error) {
+	var raw map[string]string
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		return nil, fmt.Errorf("acir: parse witness toml: %w", err)
+	}
+
+	values := make(map[uint32]string, len(raw))
+	for k, v := range raw {
+		var idx uint32
+		if _, err := fmt.Sscanf(k, "%d", &idx); err != nil {
+			return nil, fmt.Errorf("acir: witness toml key %q is not a witness index", k)
+		}
+		values[idx] = v
+	}
+
+	return &NargoWitness{WitnessValues: values}, nil
+}
+
+// Values converts every recorded witness-index -> string value into a
+// witness-index -> *big.Int map, reducing modulo the curve's scalar field
+// the way gnark's own witness parsing does.
+func (w *NargoWitness) Values(curveID ecc.ID) (map[uint32]*big.Int, error) {
+	modulus := curveID.ScalarField()
+	out := make(map[uint32]*big.Int, len(w.WitnessValues))
+	for idx, s := range w.WitnessValues {
+		v, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("acir: witness %d has invalid field element %q", idx, s)
+		}
+		v.Mod(v, modulus)
+		out[idx] = v
+	}
+	return out, nil
+}
+
+// NewWitness builds a gnark witness.Witness-compatible assignment for
+// circuit (as produced by NewCircuit) from a witness-index-keyed
+// NargoWitness (see LoadWitnessIndexTOML - not nargo's own ABI-named
+// Prover.toml; see the package doc comment), so a compiled ACIR-shaped
+// program plus its resolved witness values can be fed straight into
+// groth16.Prove/Setup without a hand-written per-program circuit.
+func NewWitness(circuit *Circuit, w *NargoWitness, curveID ecc.ID) (witness.Witness, error) {
+	values, err := w.Values(curveID)
+	if err != nil {
+		return nil, err
+	}
+	assignment := circuit.Assign(values)
+	return frontend.NewWitness(assignment, curveID.ScalarField())
+}
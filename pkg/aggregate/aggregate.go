@@ -0,0 +1,214 @@
+// Package aggregate implements the "simpler first cut" amortized-
+// verification scheme this repo's Solana exporter points at: rather than a
+// SnarkPack-style logarithmic inner-product pairing argument, it defines a
+// gnark circuit that recursively verifies N inner Groth16 proofs (all
+// against one fixed VerifyingKey) and lets the CLI emit a single outer
+// BN254 Groth16 proof attesting "all N proofs verify" - so pkg/solana's
+// existing single-proof on-chain verifier covers many underlying
+// statements with one on-chain check, instead of N.
+//
+// Recursive Groth16 verification normally wants a cycle of curves (prove
+// the inner BN254 proof inside a circuit over a curve whose native field
+// matches BN254's scalar field, e.g. BW6-761) so the in-circuit pairing
+// arithmetic is native. This package instead verifies BN254 proofs inside
+// a BN254 circuit via std/algebra/emulated/sw_bn254's non-native field
+// emulation: every inner Fp/Fp2 operation costs many native BN254
+// constraints, but the outer proof stays on the one curve the rest of
+// this CLI, pkg/solana and pkg/evm are built around - introducing a
+// second curve here would ripple through every artifact type in
+// cmd/solana-noir-verifier. Keep N in the single digits; a true
+// SnarkPack aggregator would scale further but needs pairing-based
+// recursion this repo's gnark pin doesn't expose at the public API level.
+//
+// PublicHash binds the outer proof to a specific set of inner public
+// inputs: Define reconstructs each Witnesses[i].Public element as a
+// native BN254 scalar (innerScalar is emulated.BN254Fr, which is exactly
+// the outer circuit's own native field, so an emulated element's
+// canonical limbs losslessly recompose into one native variable) and
+// feeds the reconstructed values through an in-circuit std/hash/mimc
+// hasher in the same order BuildWitness's host-side pkg/native/mimc pass
+// uses, asserting the result equals PublicHash. Without this a prover
+// could attach an arbitrary PublicHash to a validly-aggregated proof, so
+// an on-chain verifier checking only PublicHash would learn nothing
+// about which statements were actually aggregated.
+package aggregate
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/math/emulated"
+	recursion "github.com/consensys/gnark/std/recursion/groth16"
+
+	nativemimc "solana-noir-verifier/pkg/native/mimc"
+)
+
+type (
+	innerG1      = sw_bn254.G1Affine
+	innerG2      = sw_bn254.G2Affine
+	innerGT      = sw_bn254.GTEl
+	innerScalar  = sw_bn254.ScalarField
+	innerVK      = recursion.VerifyingKey[innerG1, innerG2, innerGT]
+	innerProof   = recursion.Proof[innerG1, innerG2]
+	innerWitness = recursion.Witness[innerScalar]
+)
+
+// Circuit proves that every one of its Proofs verifies against
+// VerifyingKey (assigned per witness via BuildWitness, like Proofs and
+// Witnesses - see NewCircuit) with the matching entry in Witnesses as its
+// public input vector. PublicHash is exposed as the outer proof's only
+// public input, and Define constrains it to be the MiMC hash of every
+// Witnesses[i].Public value in order - see the package doc.
+type Circuit struct {
+	VerifyingKey innerVK
+	Proofs       []innerProof
+	Witnesses    []innerWitness
+	PublicHash   frontend.Variable `gnark:",public"`
+}
+
+// NewCircuit returns a Circuit shaped to verify n proofs against
+// innerCCS's VerifyingKey, suitable for frontend.Compile: VerifyingKey,
+// Proofs and Witnesses are all gnark placeholders (no values assigned
+// yet), matching frontend.Circuit's usual compile-then-assign split.
+func NewCircuit(innerCCS constraint.ConstraintSystem, n int) *Circuit {
+	c := &Circuit{
+		VerifyingKey: recursion.PlaceholderVerifyingKey[innerG1, innerG2, innerGT](innerCCS),
+		Proofs:       make([]innerProof, n),
+		Witnesses:    make([]innerWitness, n),
+	}
+	for i := 0; i < n; i++ {
+		c.Proofs[i] = recursion.PlaceholderProof[innerG1, innerG2](innerCCS)
+		c.Witnesses[i] = recursion.PlaceholderWitness[innerScalar](innerCCS)
+	}
+	return c
+}
+
+// Define verifies every (Proofs[i], Witnesses[i]) pair against
+// VerifyingKey, then asserts PublicHash equals the in-circuit MiMC hash
+// of every Witnesses[i].Public value, in order - the same sequence
+// BuildWitness's host-side pkg/native/mimc pass hashes, so a prover
+// cannot attach a PublicHash that doesn't match the statements actually
+// aggregated.
+func (c *Circuit) Define(api frontend.API) error {
+	verifier, err := recursion.NewVerifier[innerScalar, innerG1, innerG2, innerGT](api)
+	if err != nil {
+		return err
+	}
+	field, err := emulated.NewField[innerScalar](api)
+	if err != nil {
+		return err
+	}
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	for i := range c.Proofs {
+		if err := verifier.AssertProof(c.VerifyingKey, c.Proofs[i], c.Witnesses[i]); err != nil {
+			return fmt.Errorf("inner proof %d: %w", i, err)
+		}
+		for j := range c.Witnesses[i].Public {
+			h.Write(recomposeNative(api, field, &c.Witnesses[i].Public[j]))
+		}
+	}
+	api.AssertIsEqual(h.Sum(), c.PublicHash)
+	return nil
+}
+
+// recomposeNative reconstructs e as a single native frontend.Variable.
+// innerScalar (emulated.BN254Fr) is numerically the same field this
+// outer circuit is itself built over, so ReduceStrict's canonical (< the
+// shared modulus) limb decomposition recomposes losslessly via a
+// weighted sum - the same coeff()-style pattern pkg/acir/circuit.go uses
+// for ACIR field elements, just with native limbs instead of raw bytes.
+func recomposeNative(api frontend.API, field *emulated.Field[innerScalar], e *emulated.Element[innerScalar]) frontend.Variable {
+	canonical := field.ReduceStrict(e)
+	_, bitsPerLimb := emulated.GetEffectiveFieldParams[innerScalar](api.Compiler().Field())
+
+	shift := new(big.Int).Lsh(big.NewInt(1), bitsPerLimb)
+	weight := big.NewInt(1)
+	sum := frontend.Variable(0)
+	for _, limb := range canonical.Limbs {
+		sum = api.Add(sum, api.Mul(limb, weight))
+		weight = new(big.Int).Mul(weight, shift)
+	}
+	return sum
+}
+
+// BuildWitness assigns innerVK/proofs/publicInputs into a Circuit ready
+// for frontend.NewWitness, sets its PublicHash to the public-input-hash
+// commitment (chained pkg/native/mimc over every inner public input, in
+// proof order), and also returns that hash so the caller can print or
+// check it - Define recomputes the same hash in-circuit over Witnesses,
+// so the two must match or the outer proof won't verify.
+func BuildWitness(innerVK *groth16_bn254.VerifyingKey, proofs []*groth16_bn254.Proof, publicInputs [][]*big.Int) (*Circuit, *big.Int, error) {
+	if len(proofs) != len(publicInputs) {
+		return nil, nil, fmt.Errorf("aggregate: %d proofs but %d public input vectors", len(proofs), len(publicInputs))
+	}
+
+	vk, err := recursion.ValueOfVerifyingKey[innerG1, innerG2, innerGT](innerVK)
+	if err != nil {
+		return nil, nil, fmt.Errorf("aggregate: convert inner verifying key: %w", err)
+	}
+
+	c := &Circuit{
+		VerifyingKey: vk,
+		Proofs:       make([]innerProof, len(proofs)),
+		Witnesses:    make([]innerWitness, len(proofs)),
+	}
+
+	h := nativemimc.New()
+	for i, p := range proofs {
+		proof, err := recursion.ValueOfProof[innerG1, innerG2](p)
+		if err != nil {
+			return nil, nil, fmt.Errorf("aggregate: convert proof %d: %w", i, err)
+		}
+		c.Proofs[i] = proof
+
+		pubWitness, err := publicWitness(publicInputs[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("aggregate: build public witness %d: %w", i, err)
+		}
+		w, err := recursion.ValueOfWitness[innerScalar](pubWitness)
+		if err != nil {
+			return nil, nil, fmt.Errorf("aggregate: convert public witness %d: %w", i, err)
+		}
+		c.Witnesses[i] = w
+
+		for _, v := range publicInputs[i] {
+			h.Write(v)
+		}
+	}
+	c.PublicHash = h.Sum()
+
+	return c, h.Sum(), nil
+}
+
+// publicWitnessAssignment mirrors cmd/solana-noir-verifier's own
+// publicWitnessAssignment: a bare frontend.Circuit whose only purpose is
+// to let frontend.NewWitness build a public-only witness.Witness from
+// decoded values, with no compiled circuit available.
+type publicWitnessAssignment struct {
+	Values []frontend.Variable `gnark:",public"`
+}
+
+func (a *publicWitnessAssignment) Define(api frontend.API) error { return nil }
+
+func publicWitness(values []*big.Int) (witness.Witness, error) {
+	assignment := &publicWitnessAssignment{Values: make([]frontend.Variable, len(values))}
+	for i, v := range values {
+		assignment.Values[i] = v
+	}
+	full, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+	return full.Public()
+}
@@ -0,0 +1,155 @@
+package aggregate
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+type squareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+// TestAggregateSingleProof compiles one inner Groth16 proof over
+// squareCircuit, wraps it in an aggregate.Circuit for n=1, and checks the
+// outer recursive-verification circuit itself compiles, proves and
+// verifies end to end (groth16.Setup+Prove, not just Solve - see
+// TestAggregatePublicHashBinding for a Solve-only check of the PublicHash
+// binding that runs in seconds). The outer circuit does an in-circuit
+// BN254 pairing check, so Setup+Prove over it takes minutes - well past
+// what `go test ./...`'s default per-package timeout tolerates alongside
+// every other package's tests, so this test only runs when
+// RUN_SLOW_TESTS=1 is set in the environment; CI's slow-test job must
+// pass a longer -timeout when it sets that variable.
+func TestAggregateSingleProof(t *testing.T) {
+	if os.Getenv("RUN_SLOW_TESTS") == "" {
+		t.Skip("slow: does a real groth16.Setup/Prove over an in-circuit pairing check (minutes); set RUN_SLOW_TESTS=1 (with a longer -timeout) to run it")
+	}
+
+	innerCCS, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &squareCircuit{})
+	if err != nil {
+		t.Fatalf("compile inner circuit: %v", err)
+	}
+	innerPK, innerVK, err := groth16.Setup(innerCCS)
+	if err != nil {
+		t.Fatalf("inner Setup: %v", err)
+	}
+
+	innerFull, err := frontend.NewWitness(&squareCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("inner NewWitness: %v", err)
+	}
+	innerProof, err := groth16.Prove(innerCCS, innerPK, innerFull)
+	if err != nil {
+		t.Fatalf("inner Prove: %v", err)
+	}
+
+	outer := NewCircuit(innerCCS, 1)
+	outerCCS, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, outer)
+	if err != nil {
+		t.Fatalf("compile outer circuit: %v", err)
+	}
+
+	assigned, publicHash, err := BuildWitness(
+		innerVK.(*groth16_bn254.VerifyingKey),
+		[]*groth16_bn254.Proof{innerProof.(*groth16_bn254.Proof)},
+		[][]*big.Int{{big.NewInt(9)}},
+	)
+	if err != nil {
+		t.Fatalf("BuildWitness: %v", err)
+	}
+	if publicHash == nil {
+		t.Fatal("BuildWitness returned nil publicHash")
+	}
+	assigned.PublicHash = publicHash
+
+	outerPK, outerVK, err := groth16.Setup(outerCCS)
+	if err != nil {
+		t.Fatalf("outer Setup: %v", err)
+	}
+	outerFull, err := frontend.NewWitness(assigned, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("outer NewWitness: %v", err)
+	}
+	outerProof, err := groth16.Prove(outerCCS, outerPK, outerFull)
+	if err != nil {
+		t.Fatalf("outer Prove: %v", err)
+	}
+	outerPublic, err := outerFull.Public()
+	if err != nil {
+		t.Fatalf("outer Public: %v", err)
+	}
+	if err := groth16.Verify(outerProof, outerVK, outerPublic); err != nil {
+		t.Fatalf("outer Verify: %v", err)
+	}
+}
+
+// TestAggregatePublicHashBinding checks that Define's in-circuit MiMC hash
+// over Witnesses rejects a PublicHash that doesn't match: it solves the
+// outer constraint system directly (cheap - no outer groth16.Setup/Prove,
+// so this doesn't need the RUN_SLOW_TESTS guard above) once with the
+// BuildWitness-computed PublicHash and once with it tampered.
+func TestAggregatePublicHashBinding(t *testing.T) {
+	innerCCS, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &squareCircuit{})
+	if err != nil {
+		t.Fatalf("compile inner circuit: %v", err)
+	}
+	innerPK, innerVK, err := groth16.Setup(innerCCS)
+	if err != nil {
+		t.Fatalf("inner Setup: %v", err)
+	}
+
+	innerFull, err := frontend.NewWitness(&squareCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("inner NewWitness: %v", err)
+	}
+	innerProof, err := groth16.Prove(innerCCS, innerPK, innerFull)
+	if err != nil {
+		t.Fatalf("inner Prove: %v", err)
+	}
+
+	outer := NewCircuit(innerCCS, 1)
+	outerCCS, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, outer)
+	if err != nil {
+		t.Fatalf("compile outer circuit: %v", err)
+	}
+
+	assigned, publicHash, err := BuildWitness(
+		innerVK.(*groth16_bn254.VerifyingKey),
+		[]*groth16_bn254.Proof{innerProof.(*groth16_bn254.Proof)},
+		[][]*big.Int{{big.NewInt(9)}},
+	)
+	if err != nil {
+		t.Fatalf("BuildWitness: %v", err)
+	}
+
+	assigned.PublicHash = publicHash
+	full, err := frontend.NewWitness(assigned, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("outer NewWitness: %v", err)
+	}
+	if _, err := outerCCS.Solve(full); err != nil {
+		t.Fatalf("Solve with correct PublicHash failed: %v", err)
+	}
+
+	assigned.PublicHash = new(big.Int).Add(publicHash, big.NewInt(1))
+	tampered, err := frontend.NewWitness(assigned, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("outer NewWitness (tampered): %v", err)
+	}
+	if _, err := outerCCS.Solve(tampered); err == nil {
+		t.Fatal("Solve with tampered PublicHash succeeded, want error")
+	}
+}
@@ -0,0 +1,61 @@
+// Package curves lets circuits and benchmarks compile over a chosen curve
+// instead of hardcoding ecc.BN254 (Noir's default backend, Barretenberg, is
+// BN254, but the wider ecosystem also proves over BLS12-381/BLS12-377).
+// Proving-system concerns (Groth16 vs PLONK Setup/Prove/Verify) live in
+// pkg/proofsystem, which is curve-agnostic and composes with this package.
+package curves
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// Curve wraps a gnark-crypto curve ID and is the single place that should
+// know which ecc.ID a proof was produced over.
+type Curve struct {
+	ID ecc.ID
+}
+
+// Supported curves. BN254 remains the default because it is what Noir's
+// Barretenberg backend and groth16-solana's alt_bn128 syscalls expect.
+var (
+	BN254     = Curve{ID: ecc.BN254}
+	BLS12_381 = Curve{ID: ecc.BLS12_381}
+	BLS12_377 = Curve{ID: ecc.BLS12_377}
+)
+
+// ByName resolves a --curve flag value ("bn254", "bls12-381", "bls12-377")
+// to a Curve, defaulting to BN254 when name is empty.
+func ByName(name string) (Curve, error) {
+	switch name {
+	case "", "bn254":
+		return BN254, nil
+	case "bls12-381":
+		return BLS12_381, nil
+	case "bls12-377":
+		return BLS12_377, nil
+	default:
+		return Curve{}, fmt.Errorf("unknown curve %q (want bn254, bls12-381, or bls12-377)", name)
+	}
+}
+
+func (c Curve) String() string {
+	return c.ID.String()
+}
+
+// Compile builds the constraint system for circuit over this curve's
+// scalar field.
+func (c Curve) Compile(circuit frontend.Circuit) (constraint.ConstraintSystem, error) {
+	return frontend.Compile(c.ID.ScalarField(), r1cs.NewBuilder, circuit)
+}
+
+// NewWitness builds a witness for assignment over this curve's scalar
+// field.
+func (c Curve) NewWitness(assignment frontend.Circuit) (witness.Witness, error) {
+	return frontend.NewWitness(assignment, c.ID.ScalarField())
+}
@@ -0,0 +1,59 @@
+package curves
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+type squareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestByName(t *testing.T) {
+	cases := map[string]Curve{
+		"":          BN254,
+		"bn254":     BN254,
+		"bls12-381": BLS12_381,
+		"bls12-377": BLS12_377,
+	}
+	for name, want := range cases {
+		got, err := ByName(name)
+		if err != nil {
+			t.Fatalf("ByName(%q): %v", name, err)
+		}
+		if got != want {
+			t.Errorf("ByName(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	if _, err := ByName("bls12-999"); err == nil {
+		t.Error("ByName(\"bls12-999\") succeeded, want error")
+	}
+}
+
+func TestCompileAndNewWitness(t *testing.T) {
+	for _, curve := range []Curve{BN254, BLS12_381, BLS12_377} {
+		cs, err := curve.Compile(&squareCircuit{})
+		if err != nil {
+			t.Fatalf("%s: Compile: %v", curve, err)
+		}
+		if cs.GetNbConstraints() == 0 {
+			t.Errorf("%s: compiled circuit has 0 constraints", curve)
+		}
+
+		w, err := curve.NewWitness(&squareCircuit{X: 3, Y: 9})
+		if err != nil {
+			t.Fatalf("%s: NewWitness: %v", curve, err)
+		}
+		if w.Vector() == nil {
+			t.Errorf("%s: witness has nil vector", curve)
+		}
+	}
+}
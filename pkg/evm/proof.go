@@ -0,0 +1,58 @@
+package evm
+
+import (
+	"math/big"
+
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"golang.org/x/crypto/sha3"
+
+	pkgsolana "solana-noir-verifier/pkg/solana"
+)
+
+// verifyProofSignature is writeSolidityVerifier's generated entrypoint;
+// kept here (rather than derived from the generated file) since the
+// selector only depends on the fixed function signature, not on any
+// particular verifying key's constants.
+const verifyProofSignature = "verifyProof(uint256[2],uint256[2][2],uint256[2],uint256[])"
+
+// EncodeVerifyProofCalldata ABI-encodes a ready-to-send call to the
+// Verifier.sol contract EmitVerifier generates, for proof and
+// publicInputs (in the same order EmitVerifier's IC[] constructor
+// expects them). The a/b/c head words are exactly
+// pkg/solana.MarshalProof's 256-byte output: gnark's G2 RawBytes()
+// already lays out a point as X.A1|X.A0|Y.A1|Y.A0, the same order
+// writeSolidityVerifier's BETA_X1/BETA_X0/... constants and ecPairing's
+// b argument use, so the one proof blob both chains' generated
+// verifiers consume doesn't need re-deriving here - only wrapping in
+// the selector + ABI head/tail shape a raw Ethereum call needs.
+func EncodeVerifyProofCalldata(proof *groth16_bn254.Proof, publicInputs []*big.Int) []byte {
+	proofBytes := pkgsolana.MarshalProof(proof) // a (64B) + b (128B) + c (64B) = 8 words
+
+	const fixedWords = 8                     // a: 2, b: 4, c: 2
+	inputOffset := uint64(fixedWords+1) * 32 // +1 for the input[] offset word itself
+
+	calldata := make([]byte, 0, 4+len(proofBytes)+32+32+32*len(publicInputs))
+	calldata = append(calldata, verifyProofSelector()...)
+	calldata = append(calldata, proofBytes...)
+	calldata = append(calldata, word(new(big.Int).SetUint64(inputOffset))...)
+	calldata = append(calldata, word(big.NewInt(int64(len(publicInputs))))...)
+	for _, v := range publicInputs {
+		calldata = append(calldata, word(v)...)
+	}
+	return calldata
+}
+
+// verifyProofSelector returns the 4-byte Solidity function selector for
+// verifyProof, the first 4 bytes of keccak256(verifyProofSignature).
+func verifyProofSelector() []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(verifyProofSignature))
+	return h.Sum(nil)[:4]
+}
+
+// word renders v as a 32-byte big-endian ABI word.
+func word(v *big.Int) []byte {
+	buf := make([]byte, 32)
+	v.FillBytes(buf)
+	return buf
+}
@@ -0,0 +1,92 @@
+package evm
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"golang.org/x/crypto/sha3"
+
+	pkgsolana "solana-noir-verifier/pkg/solana"
+)
+
+type squareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+// TestEncodeVerifyProofCalldataMatchesABI proves a real circuit, encodes
+// the result, and checks the calldata byte-for-byte against what
+// Verifier.sol's verifyProof(uint256[2],uint256[2][2],uint256[2],uint256[])
+// expects: a 4-byte selector, the fixed a/b/c head words (identical to
+// pkg/solana.MarshalProof's output, so the same proof blob works for
+// both generated verifiers), an offset word pointing at the dynamic
+// input[] tail, and the public inputs themselves.
+func TestEncodeVerifyProofCalldataMatchesABI(t *testing.T) {
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &squareCircuit{})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	pk, _, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	full, err := frontend.NewWitness(&squareCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("NewWitness: %v", err)
+	}
+	proof, err := groth16.Prove(cs, pk, full)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+	p := proof.(*groth16_bn254.Proof)
+	publicInputs := []*big.Int{big.NewInt(9)}
+
+	calldata := EncodeVerifyProofCalldata(p, publicInputs)
+
+	wantSelector := func() []byte {
+		h := sha3.NewLegacyKeccak256()
+		h.Write([]byte("verifyProof(uint256[2],uint256[2][2],uint256[2],uint256[])"))
+		return h.Sum(nil)[:4]
+	}()
+	if !bytes.Equal(calldata[0:4], wantSelector) {
+		t.Errorf("selector = %x, want %x", calldata[0:4], wantSelector)
+	}
+
+	wantProofBytes := pkgsolana.MarshalProof(p)
+	if !bytes.Equal(calldata[4:4+256], wantProofBytes) {
+		t.Error("a/b/c head words do not match pkg/solana.MarshalProof's output")
+	}
+
+	offset := new(big.Int).SetBytes(calldata[4+256 : 4+256+32])
+	if offset.Cmp(big.NewInt(9*32)) != 0 {
+		t.Errorf("input[] offset = %s, want %d", offset, 9*32)
+	}
+
+	length := new(big.Int).SetBytes(calldata[4+256+32 : 4+256+64])
+	if length.Cmp(big.NewInt(int64(len(publicInputs)))) != 0 {
+		t.Errorf("input[] length = %s, want %d", length, len(publicInputs))
+	}
+
+	wantLen := 4 + 256 + 32 + 32 + 32*len(publicInputs)
+	if len(calldata) != wantLen {
+		t.Fatalf("len(calldata) = %d, want %d", len(calldata), wantLen)
+	}
+	for i, v := range publicInputs {
+		start := 4 + 256 + 64 + 32*i
+		got := new(big.Int).SetBytes(calldata[start : start+32])
+		if got.Cmp(v) != 0 {
+			t.Errorf("input[%d] = %s, want %s", i, got, v)
+		}
+	}
+}
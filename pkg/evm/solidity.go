@@ -0,0 +1,152 @@
+// Package evm generates a self-contained Solidity verifier contract for a
+// Groth16 BN254 verifying key - the EVM-chain counterpart to pkg/solana's
+// Anchor program. Both emitters read the same vk.bin produced by the CLI's
+// `setup` step; only the target chain's precompiles differ (alt_bn128_*
+// syscalls on Solana vs ecAdd/ecMul/ecPairing at 0x06/0x07/0x08 on EVM
+// chains).
+package evm
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// EmitVerifier writes a self-contained Verifier.sol to outDir implementing
+// verifyProof against vk, the Solidity sibling of pkg/solana.EmitProgram.
+func EmitVerifier(vk *groth16_bn254.VerifyingKey, outDir string) error {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("create output dir: %w", err)
+	}
+	return writeSolidityVerifier(vk, filepath.Join(outDir, "Verifier.sol"))
+}
+
+// writeSolidityVerifier emits the standard snarkjs-shaped Groth16 verifier:
+// alpha/beta/gamma/delta and IC[] baked in as constants, and a
+// verifyProof(a, b, c, input) entrypoint built on the ecAdd/ecMul/ecPairing
+// precompiles. It expects proof.a to already be negated (the same
+// negated-A convention pkg/solana.MarshalProof uses for Solana), so the
+// same Groth16 proof can be re-encoded for either chain from one negated
+// A point without re-running the prover.
+func writeSolidityVerifier(vk *groth16_bn254.VerifyingKey, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	nrPubInputs := len(vk.G1.K) - 1
+
+	fmt.Fprintln(f, "// SPDX-License-Identifier: MIT")
+	fmt.Fprintln(f, "// Generated EVM verifier for a Groth16 BN254 circuit.")
+	fmt.Fprintln(f, "// Pairing check: e(A, B) = e(alpha, beta) * e(vk_x, gamma) * e(C, delta)")
+	fmt.Fprintln(f, "// where vk_x = IC[0] + sum_i input[i] * IC[i+1], and A is expected")
+	fmt.Fprintln(f, "// pre-negated, matching pkg/solana.MarshalProof's convention.")
+	fmt.Fprintln(f, "pragma solidity ^0.8.19;")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "contract Verifier {")
+	fmt.Fprintln(f, "    uint256 constant PRIME_Q =")
+	fmt.Fprintln(f, "        21888242871839275222246405745257275088696311157297823662689037894645226208583;")
+	fmt.Fprintln(f, "")
+	fmt.Fprintf(f, "    uint256 constant ALPHA_X = %s;\n", coord(&vk.G1.Alpha.X))
+	fmt.Fprintf(f, "    uint256 constant ALPHA_Y = %s;\n", coord(&vk.G1.Alpha.Y))
+	fmt.Fprintf(f, "    uint256 constant BETA_X1 = %s;\n", coord(&vk.G2.Beta.X.A1))
+	fmt.Fprintf(f, "    uint256 constant BETA_X0 = %s;\n", coord(&vk.G2.Beta.X.A0))
+	fmt.Fprintf(f, "    uint256 constant BETA_Y1 = %s;\n", coord(&vk.G2.Beta.Y.A1))
+	fmt.Fprintf(f, "    uint256 constant BETA_Y0 = %s;\n", coord(&vk.G2.Beta.Y.A0))
+	fmt.Fprintf(f, "    uint256 constant GAMMA_X1 = %s;\n", coord(&vk.G2.Gamma.X.A1))
+	fmt.Fprintf(f, "    uint256 constant GAMMA_X0 = %s;\n", coord(&vk.G2.Gamma.X.A0))
+	fmt.Fprintf(f, "    uint256 constant GAMMA_Y1 = %s;\n", coord(&vk.G2.Gamma.Y.A1))
+	fmt.Fprintf(f, "    uint256 constant GAMMA_Y0 = %s;\n", coord(&vk.G2.Gamma.Y.A0))
+	fmt.Fprintf(f, "    uint256 constant DELTA_X1 = %s;\n", coord(&vk.G2.Delta.X.A1))
+	fmt.Fprintf(f, "    uint256 constant DELTA_X0 = %s;\n", coord(&vk.G2.Delta.X.A0))
+	fmt.Fprintf(f, "    uint256 constant DELTA_Y1 = %s;\n", coord(&vk.G2.Delta.Y.A1))
+	fmt.Fprintf(f, "    uint256 constant DELTA_Y0 = %s;\n", coord(&vk.G2.Delta.Y.A0))
+	fmt.Fprintln(f, "")
+	fmt.Fprintf(f, "    uint256 constant NR_PUBINPUTS = %d;\n", nrPubInputs)
+	fmt.Fprintln(f, "")
+	fmt.Fprintf(f, "    uint256[2][%d] IC;\n", nrPubInputs+1)
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "    constructor() {")
+	for i, ic := range vk.G1.K {
+		fmt.Fprintf(f, "        IC[%d] = [uint256(%s), uint256(%s)];\n", i, coord(&ic.X), coord(&ic.Y))
+	}
+	fmt.Fprintln(f, "    }")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "    function verifyProof(")
+	fmt.Fprintln(f, "        uint256[2] memory a,")
+	fmt.Fprintln(f, "        uint256[2][2] memory b,")
+	fmt.Fprintln(f, "        uint256[2] memory c,")
+	fmt.Fprintln(f, "        uint256[] memory input")
+	fmt.Fprintln(f, "    ) public view returns (bool) {")
+	fmt.Fprintln(f, "        require(input.length == NR_PUBINPUTS, \"bad public input count\");")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "        uint256[2] memory vkX = IC[0];")
+	fmt.Fprintln(f, "        for (uint256 i = 0; i < input.length; i++) {")
+	fmt.Fprintln(f, "            require(input[i] < PRIME_Q, \"public input out of range\");")
+	fmt.Fprintln(f, "            vkX = ecAdd(vkX, ecMul(IC[i + 1], input[i]));")
+	fmt.Fprintln(f, "        }")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "        return ecPairing(")
+	fmt.Fprintln(f, "            a, b,")
+	fmt.Fprintln(f, "            [ALPHA_X, ALPHA_Y], [[BETA_X1, BETA_X0], [BETA_Y1, BETA_Y0]],")
+	fmt.Fprintln(f, "            vkX, [[GAMMA_X1, GAMMA_X0], [GAMMA_Y1, GAMMA_Y0]],")
+	fmt.Fprintln(f, "            c, [[DELTA_X1, DELTA_X0], [DELTA_Y1, DELTA_Y0]]")
+	fmt.Fprintln(f, "        );")
+	fmt.Fprintln(f, "    }")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "    function ecAdd(uint256[2] memory p1, uint256[2] memory p2) internal view returns (uint256[2] memory r) {")
+	fmt.Fprintln(f, "        uint256[4] memory input = [p1[0], p1[1], p2[0], p2[1]];")
+	fmt.Fprintln(f, "        bool ok;")
+	fmt.Fprintln(f, "        assembly {")
+	fmt.Fprintln(f, "            ok := staticcall(gas(), 0x06, input, 0x80, r, 0x40)")
+	fmt.Fprintln(f, "        }")
+	fmt.Fprintln(f, "        require(ok, \"ecAdd failed\");")
+	fmt.Fprintln(f, "    }")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "    function ecMul(uint256[2] memory p, uint256 s) internal view returns (uint256[2] memory r) {")
+	fmt.Fprintln(f, "        uint256[3] memory input = [p[0], p[1], s];")
+	fmt.Fprintln(f, "        bool ok;")
+	fmt.Fprintln(f, "        assembly {")
+	fmt.Fprintln(f, "            ok := staticcall(gas(), 0x07, input, 0x60, r, 0x40)")
+	fmt.Fprintln(f, "        }")
+	fmt.Fprintln(f, "        require(ok, \"ecMul failed\");")
+	fmt.Fprintln(f, "    }")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "    // ecPairing checks e(a1,a2) * e(b1,b2) * e(c1,c2) * e(d1,d2) == 1, i.e.")
+	fmt.Fprintln(f, "    // e(-A, B) * e(alpha, beta) * e(vkX, gamma) * e(C, delta) == 1, so the")
+	fmt.Fprintln(f, "    // caller is expected to pass a1 already negated (matching the")
+	fmt.Fprintln(f, "    // negated-A convention pkg/solana.MarshalProof also uses).")
+	fmt.Fprintln(f, "    function ecPairing(")
+	fmt.Fprintln(f, "        uint256[2] memory a1, uint256[2][2] memory a2,")
+	fmt.Fprintln(f, "        uint256[2] memory b1, uint256[2][2] memory b2,")
+	fmt.Fprintln(f, "        uint256[2] memory c1, uint256[2][2] memory c2,")
+	fmt.Fprintln(f, "        uint256[2] memory d1, uint256[2][2] memory d2")
+	fmt.Fprintln(f, "    ) internal view returns (bool) {")
+	fmt.Fprintln(f, "        uint256[24] memory input = [")
+	fmt.Fprintln(f, "            a1[0], a1[1], a2[0][0], a2[0][1], a2[1][0], a2[1][1],")
+	fmt.Fprintln(f, "            b1[0], b1[1], b2[0][0], b2[0][1], b2[1][0], b2[1][1],")
+	fmt.Fprintln(f, "            c1[0], c1[1], c2[0][0], c2[0][1], c2[1][0], c2[1][1],")
+	fmt.Fprintln(f, "            d1[0], d1[1], d2[0][0], d2[0][1], d2[1][0], d2[1][1]")
+	fmt.Fprintln(f, "        ];")
+	fmt.Fprintln(f, "        uint256[1] memory out;")
+	fmt.Fprintln(f, "        bool ok;")
+	fmt.Fprintln(f, "        assembly {")
+	fmt.Fprintln(f, "            ok := staticcall(gas(), 0x08, input, 0x300, out, 0x20)")
+	fmt.Fprintln(f, "        }")
+	fmt.Fprintln(f, "        require(ok, \"ecPairing failed\");")
+	fmt.Fprintln(f, "        return out[0] == 1;")
+	fmt.Fprintln(f, "    }")
+	fmt.Fprintln(f, "}")
+
+	return nil
+}
+
+// coord renders a base-field coordinate as a decimal string, the format
+// snarkjs-generated verifiers and this contract's constants both use.
+func coord(e interface{ BigInt(*big.Int) *big.Int }) string {
+	return e.BigInt(new(big.Int)).String()
+}
@@ -0,0 +1,106 @@
+// Package mimc computes MiMC out-of-circuit, over BN254, using exactly the
+// same round constants and Feistel permutation (f(x) = (x + k + c_i)^7) as
+// gnark-crypto's native implementation that gnark's in-circuit
+// std/hash/mimc.MiMC gadget is built to match. Benchmarks that also run a
+// circuit using std/hash/mimc should use this package to compute their
+// expected witness values, instead of a placeholder like `current * 7 mod
+// r`, so the prove step runs against a real witness.
+package mimc
+
+import (
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// Hash wraps gnark-crypto's native BN254 MiMC hash.Hash, exposing the same
+// Write/Reset/Sum shape as the in-circuit gadget so call sites read the
+// same way whether they are building a witness or a circuit.
+type Hash struct {
+	inner hash.Hash
+}
+
+// New returns a fresh native MiMC hash state.
+func New() *Hash {
+	return &Hash{inner: mimc.NewMiMC()}
+}
+
+// Reset clears all accumulated state, mirroring the in-circuit gadget's
+// Reset before starting a new permutation chain.
+func (h *Hash) Reset() {
+	h.inner.Reset()
+}
+
+// Write absorbs one field element, reducing it modulo the scalar field
+// first (matching how the in-circuit gadget treats a frontend.Variable
+// input).
+func (h *Hash) Write(x *big.Int) {
+	buf := make([]byte, 32)
+	new(big.Int).Mod(x, mimcModulus()).FillBytes(buf)
+	h.inner.Write(buf)
+}
+
+// Sum returns the current MiMC output as a field element, the native
+// equivalent of the in-circuit gadget's Sum().
+func (h *Hash) Sum() *big.Int {
+	out := h.inner.Sum(nil)
+	return new(big.Int).SetBytes(out)
+}
+
+func mimcModulus() *big.Int {
+	// BN254 scalar field modulus; duplicated here (rather than importing
+	// ecc.BN254.ScalarField()) to keep this package's only gnark-crypto
+	// dependency the mimc hash itself.
+	m, _ := new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+	return m
+}
+
+// Sum1 is a convenience for hashing a single field element, as used by a
+// MiMC hash chain: h.Reset(); h.Write(x); return h.Sum().
+func Sum1(x *big.Int) *big.Int {
+	h := New()
+	h.Write(x)
+	return h.Sum()
+}
+
+// Sum2 is a convenience for hashing a pair of field elements in one
+// permutation, as MerkleProofCircuit's h.Write(left, right) does.
+func Sum2(left, right *big.Int) *big.Int {
+	h := New()
+	h.Write(left)
+	h.Write(right)
+	return h.Sum()
+}
+
+// Chain computes a length-n MiMC hash chain starting from preImage:
+// hashes[0] = MiMC(preImage), hashes[i] = MiMC(hashes[i-1]). This mirrors
+// MiMCHashChainCircuit.Define's loop exactly, so the returned slice is a
+// valid public witness for that circuit.
+func Chain(preImage *big.Int, n int) []*big.Int {
+	hashes := make([]*big.Int, n)
+	current := preImage
+	for i := 0; i < n; i++ {
+		current = Sum1(current)
+		hashes[i] = current
+	}
+	return hashes
+}
+
+// MerklePath computes the root of a Merkle path the same way
+// MerkleProofCircuit.Define does: at each level, select (left, right) from
+// (current, sibling) based on pathBits[i] (0 = current is left child, 1 =
+// current is right child), hash the pair, and continue.
+func MerklePath(leaf *big.Int, siblings []*big.Int, pathBits []int) *big.Int {
+	current := leaf
+	for i, sibling := range siblings {
+		var left, right *big.Int
+		if pathBits[i] == 0 {
+			left, right = current, sibling
+		} else {
+			left, right = sibling, current
+		}
+		current = Sum2(left, right)
+	}
+	return current
+}
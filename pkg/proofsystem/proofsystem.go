@@ -0,0 +1,172 @@
+// Package proofsystem abstracts over gnark's Groth16 and PLONK backends so
+// benchmarks and circuits can run through either without hardcoding
+// groth16.Setup/Prove/Verify, the way the benchmark suite used to.
+package proofsystem
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/plonk"
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test/unsafekzg"
+
+	pkgsolana "solana-noir-verifier/pkg/solana"
+)
+
+// ProvingKey, VerifyingKey and Proof are opaque handles: Groth16 and PLONK
+// use distinct concrete types (groth16.ProvingKey vs plonk.ProvingKey, etc)
+// with no shared interface beyond io.WriterTo/io.ReaderFrom, so callers
+// that need the concrete type should type-assert based on which System
+// produced the value.
+type ProvingKey = interface{}
+type VerifyingKey = interface{}
+type Proof = interface{}
+
+// System is implemented by each supported backend. It is the abstraction
+// the solana-noir-verifier CLI (cmd/solana-noir-verifier) is built around,
+// so a --backend=groth16|plonk flag is the only place that needs to know
+// which concrete backend is running; everything downstream (compile,
+// setup, prove, verify, export-solana) goes through this interface instead
+// of casting to groth16_bn254.Proof the way the CLI used to.
+type System interface {
+	Name() string
+	Compile(curve ecc.ID, circuit frontend.Circuit) (constraint.ConstraintSystem, error)
+	Setup(cs constraint.ConstraintSystem) (ProvingKey, VerifyingKey, error)
+	Prove(cs constraint.ConstraintSystem, pk ProvingKey, witness witness.Witness) (Proof, error)
+	Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness) error
+	MarshalProof(proof Proof) ([]byte, error)
+	ExportSolana(vk VerifyingKey, outDir string) error
+}
+
+// All is the set of backends the benchmark harness compares.
+var All = []System{Groth16{}, Plonk{}}
+
+// ByName resolves a --backend flag value to a System, defaulting to Groth16
+// when name is empty.
+func ByName(name string) (System, error) {
+	switch name {
+	case "", "groth16":
+		return Groth16{}, nil
+	case "plonk":
+		return Plonk{}, nil
+	default:
+		return nil, fmt.Errorf("unknown backend %q (want groth16 or plonk)", name)
+	}
+}
+
+// Groth16 wraps gnark's backend/groth16 behind the System interface.
+type Groth16 struct{}
+
+func (Groth16) Name() string { return "groth16" }
+
+func (Groth16) Compile(curve ecc.ID, circuit frontend.Circuit) (constraint.ConstraintSystem, error) {
+	return frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, circuit)
+}
+
+func (Groth16) Setup(cs constraint.ConstraintSystem) (ProvingKey, VerifyingKey, error) {
+	return groth16.Setup(cs)
+}
+
+func (Groth16) Prove(cs constraint.ConstraintSystem, pk ProvingKey, w witness.Witness) (Proof, error) {
+	return groth16.Prove(cs, pk.(groth16.ProvingKey), w)
+}
+
+func (Groth16) Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness) error {
+	return groth16.Verify(proof.(groth16.Proof), vk.(groth16.VerifyingKey), publicWitness)
+}
+
+// MarshalProof returns proof in the 256-byte layout (negated-A G1 ||
+// G2 || G1) both pkg/solana's Anchor verifier and pkg/evm's Solidity
+// verifier consume directly - see pkg/solana.MarshalProof's doc comment
+// for why the same bytes work for both chains' alt_bn128/ecPairing
+// precompiles - rather than gnark's own internal binary proof encoding,
+// which neither generated verifier can parse.
+func (Groth16) MarshalProof(proof Proof) ([]byte, error) {
+	concrete, ok := proof.(*groth16_bn254.Proof)
+	if !ok {
+		return nil, fmt.Errorf("proofsystem: groth16 proof is %T, not BN254", proof)
+	}
+	return pkgsolana.MarshalProof(concrete), nil
+}
+
+// ExportSolana emits the alt_bn128-syscall Anchor verifier program this
+// repo has shipped since chunk0-1, unchanged.
+func (Groth16) ExportSolana(vk VerifyingKey, outDir string) error {
+	concrete, ok := vk.(*groth16_bn254.VerifyingKey)
+	if !ok {
+		return fmt.Errorf("proofsystem: groth16 verifying key is %T, not BN254", vk)
+	}
+	return pkgsolana.EmitProgram(concrete, outDir)
+}
+
+// Plonk wraps gnark's backend/plonk behind the System interface. The KZG
+// SRS is generated in-process with unsafekzg.NewSRS, which is fine for
+// benchmarking but is not a trusted setup suitable for production use
+// (pkg/ptau parses Phase 1 ceremony files but feeds no setup, for either
+// backend).
+type Plonk struct{}
+
+func (Plonk) Name() string { return "plonk" }
+
+func (Plonk) Compile(curve ecc.ID, circuit frontend.Circuit) (constraint.ConstraintSystem, error) {
+	return frontend.Compile(curve.ScalarField(), scs.NewBuilder, circuit)
+}
+
+func (Plonk) Setup(cs constraint.ConstraintSystem) (ProvingKey, VerifyingKey, error) {
+	srs, srsLagrange, err := unsafekzg.NewSRS(cs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("proofsystem: generate KZG SRS: %w", err)
+	}
+	return plonk.Setup(cs, srs, srsLagrange)
+}
+
+func (Plonk) Prove(cs constraint.ConstraintSystem, pk ProvingKey, w witness.Witness) (Proof, error) {
+	return plonk.Prove(cs, pk.(plonk.ProvingKey), w)
+}
+
+func (Plonk) Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness) error {
+	return plonk.Verify(proof.(plonk.Proof), vk.(plonk.VerifyingKey), publicWitness)
+}
+
+func (Plonk) MarshalProof(proof Proof) ([]byte, error) {
+	return marshalWriterTo(proof)
+}
+
+// ExportSolana emits an Anchor program scaffold for a PLONK verifying key:
+// a companion Rust VK struct holding the gnark-serialized VK bytes, and the
+// verify instruction schema. It emits no proof blob (`prove` writes PLONK
+// proofs in gnark's own encoding), and the generated verify instruction
+// checks only the public input count before returning NotYetImplemented;
+// it runs no KZG pairing check (see pkgsolana.EmitPlonkProgram's doc
+// comment).
+func (Plonk) ExportSolana(vk VerifyingKey, outDir string) error {
+	concrete, ok := vk.(*plonk_bn254.VerifyingKey)
+	if !ok {
+		return fmt.Errorf("proofsystem: plonk verifying key is %T, not BN254", vk)
+	}
+	return pkgsolana.EmitPlonkProgram(concrete, outDir)
+}
+
+// marshalWriterTo serializes any proof that implements io.WriterTo, which
+// both groth16.Proof and plonk.Proof do.
+func marshalWriterTo(proof Proof) ([]byte, error) {
+	w, ok := proof.(io.WriterTo)
+	if !ok {
+		return nil, fmt.Errorf("proofsystem: proof type %T does not implement io.WriterTo", proof)
+	}
+	var buf bytes.Buffer
+	if _, err := w.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
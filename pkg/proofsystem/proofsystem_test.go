@@ -0,0 +1,79 @@
+package proofsystem
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+
+	pkgsolana "solana-noir-verifier/pkg/solana"
+)
+
+type squareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestByName(t *testing.T) {
+	if sys, err := ByName(""); err != nil || sys.Name() != "groth16" {
+		t.Errorf("ByName(\"\") = %v, %v, want groth16 backend", sys, err)
+	}
+	if sys, err := ByName("plonk"); err != nil || sys.Name() != "plonk" {
+		t.Errorf("ByName(\"plonk\") = %v, %v, want plonk backend", sys, err)
+	}
+	if _, err := ByName("bulletproofs"); err == nil {
+		t.Error("ByName(\"bulletproofs\") succeeded, want error")
+	}
+}
+
+func TestSetupProveVerifyRoundTrip(t *testing.T) {
+	for _, sys := range All {
+		sys := sys
+		t.Run(sys.Name(), func(t *testing.T) {
+			cs, err := sys.Compile(ecc.BN254, &squareCircuit{})
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+
+			pk, vk, err := sys.Setup(cs)
+			if err != nil {
+				t.Fatalf("Setup: %v", err)
+			}
+
+			full, err := frontend.NewWitness(&squareCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+			if err != nil {
+				t.Fatalf("NewWitness: %v", err)
+			}
+			proof, err := sys.Prove(cs, pk, full)
+			if err != nil {
+				t.Fatalf("Prove: %v", err)
+			}
+
+			public, err := full.Public()
+			if err != nil {
+				t.Fatalf("Public: %v", err)
+			}
+			if err := sys.Verify(proof, vk, public); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+
+			marshaled, err := sys.MarshalProof(proof)
+			if err != nil {
+				t.Fatalf("MarshalProof: %v", err)
+			}
+			if sys.Name() == "groth16" {
+				want := pkgsolana.MarshalProof(proof.(*groth16_bn254.Proof))
+				if !bytes.Equal(marshaled, want) {
+					t.Error("Groth16.MarshalProof does not match pkg/solana.MarshalProof - the generated Anchor/Solidity verifiers would reject it")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Package ptau parses Perpetual Powers of Tau ceremony files (the .ptau
+// binary format produced by snarkjs), as a first step toward seeding a
+// Groth16 trusted setup from a public multi-party ceremony (e.g. the
+// Hermez/Aztec ptau files) instead of the single-party toxic waste the
+// CLI's `setup` step generates in-process via groth16.Setup(cs).
+//
+// Status: the ceremony-derived-setup request this package was added for
+// is still open, not delivered. ReadPtau only covers ingestion - parsing
+// a .ptau file into its raw tau-power points - and has no consumer
+// anywhere in this tree. Turning a parsed SRS into a circuit-specific
+// pk/vk needs a Phase 2 basis-change (blinding the ceremony's tau-power
+// monomials into per-circuit A/B/C evaluations) that gnark's public
+// groth16 API doesn't expose. An earlier version of this package faked
+// that step (deriving toxic waste from operator-supplied entropy and
+// hashing a "transcript" next to an ordinary groth16.Setup(cs) call,
+// which produces a pk/vk with no real relationship to the ingested
+// ceremony); that was removed as actively misleading rather than
+// finished, in favor of this honest gap note. There is no
+// Phase2Contribute entry point here, and `setup` has no --ptau flag,
+// until gnark's basis-change machinery is actually wired up. See
+// ../../BACKLOG_STATUS.md, which tracks this request_id as still open
+// so the tagged commit on this package doesn't read as having
+// delivered the ceremony-derived-setup feature it was added for.
+package ptau
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+const ptauMagic = "ptau"
+
+// Header is Phase 1's header section: the field's byte width, its prime
+// modulus, and the ceremony's power (2^power is the largest circuit size
+// the ceremony supports).
+type Header struct {
+	N8    uint32
+	Prime []byte
+	Power uint32
+}
+
+// SRS is the Phase 1 structured reference string read from a .ptau file.
+// Points are kept as the raw bytes stored in the file (64 bytes per G1
+// point, 128 per G2 point), with no decoding or byte-order conversion,
+// rather than decoded into gnark-crypto curve points here, so a ReadPtau call stays a cheap format
+// check; a future Phase 2 basis-change is what would need actual group
+// elements.
+type SRS struct {
+	Header     Header
+	TauG1      [][]byte // 64 bytes each, 2^(power+1) - 1 points
+	TauG2      [][]byte // 128 bytes each, 2^power points
+	AlphaTauG1 [][]byte // 64 bytes each, 2^power points
+	BetaTauG1  [][]byte // 64 bytes each, 2^power points
+	BetaG2     []byte   // 128 bytes, single point
+}
+
+// section records where a ptau section's payload lives in the file, as
+// found while walking the (type, size) section headers, each of which is
+// immediately followed by its payload.
+type section struct {
+	offset int64
+	size   uint64
+}
+
+const (
+	sectionHeader     = 1
+	sectionTauG1      = 2
+	sectionTauG2      = 3
+	sectionAlphaTauG1 = 4
+	sectionBetaTauG1  = 5
+	sectionBetaG2     = 6
+)
+
+// ReadPtau parses a snarkjs-format Phase 1 ceremony file: a 4-byte magic,
+// a version, a section count, then that many sections, each a (type,
+// size) header immediately followed by its payload. It records every
+// payload's offset while walking the sections, then seeks back into them.
+func ReadPtau(path string) (*SRS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return nil, fmt.Errorf("ptau: read magic: %w", err)
+	}
+	if string(magic) != ptauMagic {
+		return nil, fmt.Errorf("ptau: bad magic %q, want %q", magic, ptauMagic)
+	}
+
+	var version uint32
+	if err := binary.Read(f, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("ptau: read version: %w", err)
+	}
+
+	var numSections uint32
+	if err := binary.Read(f, binary.LittleEndian, &numSections); err != nil {
+		return nil, fmt.Errorf("ptau: read section count: %w", err)
+	}
+
+	sections := make(map[uint32]section, numSections)
+	for i := uint32(0); i < numSections; i++ {
+		var kind uint32
+		var size uint64
+		if err := binary.Read(f, binary.LittleEndian, &kind); err != nil {
+			return nil, fmt.Errorf("ptau: read section %d type: %w", i, err)
+		}
+		if err := binary.Read(f, binary.LittleEndian, &size); err != nil {
+			return nil, fmt.Errorf("ptau: read section %d size: %w", i, err)
+		}
+		offset, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		sections[kind] = section{offset: offset, size: size}
+		if _, err := f.Seek(int64(size), io.SeekCurrent); err != nil {
+			return nil, fmt.Errorf("ptau: skip section %d: %w", i, err)
+		}
+	}
+
+	header, err := readHeaderSection(f, sections)
+	if err != nil {
+		return nil, err
+	}
+
+	power := header.Power
+	srs := &SRS{Header: *header}
+
+	if srs.TauG1, err = readPoints(f, sections, sectionTauG1, 64, (1<<(power+1))-1); err != nil {
+		return nil, fmt.Errorf("ptau: tauG1: %w", err)
+	}
+	if srs.TauG2, err = readPoints(f, sections, sectionTauG2, 128, 1<<power); err != nil {
+		return nil, fmt.Errorf("ptau: tauG2: %w", err)
+	}
+	if srs.AlphaTauG1, err = readPoints(f, sections, sectionAlphaTauG1, 64, 1<<power); err != nil {
+		return nil, fmt.Errorf("ptau: alphaTauG1: %w", err)
+	}
+	if srs.BetaTauG1, err = readPoints(f, sections, sectionBetaTauG1, 64, 1<<power); err != nil {
+		return nil, fmt.Errorf("ptau: betaTauG1: %w", err)
+	}
+	betaG2, err := readPoints(f, sections, sectionBetaG2, 128, 1)
+	if err != nil {
+		return nil, fmt.Errorf("ptau: betaG2: %w", err)
+	}
+	srs.BetaG2 = betaG2[0]
+
+	return srs, nil
+}
+
+func readHeaderSection(f *os.File, sections map[uint32]section) (*Header, error) {
+	sec, ok := sections[sectionHeader]
+	if !ok {
+		return nil, fmt.Errorf("ptau: missing header section")
+	}
+	if _, err := f.Seek(sec.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var n8 uint32
+	if err := binary.Read(f, binary.LittleEndian, &n8); err != nil {
+		return nil, err
+	}
+	prime := make([]byte, n8)
+	if _, err := io.ReadFull(f, prime); err != nil {
+		return nil, err
+	}
+	var power uint32
+	if err := binary.Read(f, binary.LittleEndian, &power); err != nil {
+		return nil, err
+	}
+	return &Header{N8: n8, Prime: prime, Power: power}, nil
+}
+
+// readPoints reads n fixed-size points from the section with the given
+// kind; all four G1/G2 power vectors share this layout, differing only in
+// section number, point size and count.
+func readPoints(f *os.File, sections map[uint32]section, kind uint32, pointSize int, n uint32) ([][]byte, error) {
+	sec, ok := sections[kind]
+	if !ok {
+		return nil, fmt.Errorf("missing section %d", kind)
+	}
+	if _, err := f.Seek(sec.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	points := make([][]byte, n)
+	for i := uint32(0); i < n; i++ {
+		p := make([]byte, pointSize)
+		if _, err := io.ReadFull(f, p); err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+		points[i] = p
+	}
+	return points, nil
+}
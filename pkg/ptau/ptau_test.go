@@ -0,0 +1,88 @@
+package ptau
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSection appends a (kind, size) section header followed by payload,
+// so the caller can lay sections out in file order exactly like snarkjs does.
+func writeSection(buf *bytes.Buffer, kind uint32, payload []byte) {
+	binary.Write(buf, binary.LittleEndian, kind)
+	binary.Write(buf, binary.LittleEndian, uint64(len(payload)))
+	buf.Write(payload)
+}
+
+// buildPtau writes a minimal (power=0) .ptau file: one point per
+// TauG1/TauG2/AlphaTauG1/BetaTauG1 vector and a single BetaG2 point, the
+// smallest shape ReadPtau's section-table walk can exercise.
+func buildPtau(t *testing.T) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	buf.WriteString(ptauMagic)
+	binary.Write(&buf, binary.LittleEndian, uint32(1)) // version
+	binary.Write(&buf, binary.LittleEndian, uint32(6)) // numSections
+
+	prime := bytes.Repeat([]byte{0xAB}, 32)
+	var header bytes.Buffer
+	binary.Write(&header, binary.LittleEndian, uint32(32))
+	header.Write(prime)
+	binary.Write(&header, binary.LittleEndian, uint32(0)) // power
+	writeSection(&buf, sectionHeader, header.Bytes())
+
+	writeSection(&buf, sectionTauG1, bytes.Repeat([]byte{0x01}, 64))
+	writeSection(&buf, sectionTauG2, bytes.Repeat([]byte{0x02}, 128))
+	writeSection(&buf, sectionAlphaTauG1, bytes.Repeat([]byte{0x03}, 64))
+	writeSection(&buf, sectionBetaTauG1, bytes.Repeat([]byte{0x04}, 64))
+	writeSection(&buf, sectionBetaG2, bytes.Repeat([]byte{0x05}, 128))
+
+	path := filepath.Join(t.TempDir(), "test.ptau")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write ptau fixture: %v", err)
+	}
+	return path
+}
+
+func TestReadPtauRoundTrip(t *testing.T) {
+	srs, err := ReadPtau(buildPtau(t))
+	if err != nil {
+		t.Fatalf("ReadPtau: %v", err)
+	}
+
+	if srs.Header.N8 != 32 || srs.Header.Power != 0 {
+		t.Errorf("Header = %+v, want N8=32 Power=0", srs.Header)
+	}
+	if !bytes.Equal(srs.Header.Prime, bytes.Repeat([]byte{0xAB}, 32)) {
+		t.Errorf("Header.Prime mismatch")
+	}
+
+	if len(srs.TauG1) != 1 || !bytes.Equal(srs.TauG1[0], bytes.Repeat([]byte{0x01}, 64)) {
+		t.Errorf("TauG1 = %v, want one 0x01-filled point", srs.TauG1)
+	}
+	if len(srs.TauG2) != 1 || !bytes.Equal(srs.TauG2[0], bytes.Repeat([]byte{0x02}, 128)) {
+		t.Errorf("TauG2 = %v, want one 0x02-filled point", srs.TauG2)
+	}
+	if len(srs.AlphaTauG1) != 1 || !bytes.Equal(srs.AlphaTauG1[0], bytes.Repeat([]byte{0x03}, 64)) {
+		t.Errorf("AlphaTauG1 mismatch")
+	}
+	if len(srs.BetaTauG1) != 1 || !bytes.Equal(srs.BetaTauG1[0], bytes.Repeat([]byte{0x04}, 64)) {
+		t.Errorf("BetaTauG1 mismatch")
+	}
+	if !bytes.Equal(srs.BetaG2, bytes.Repeat([]byte{0x05}, 128)) {
+		t.Errorf("BetaG2 mismatch")
+	}
+}
+
+func TestReadPtauBadMagic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.ptau")
+	if err := os.WriteFile(path, []byte("nope"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadPtau(path); err == nil {
+		t.Error("ReadPtau(bad magic) succeeded, want error")
+	}
+}
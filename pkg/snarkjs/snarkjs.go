@@ -0,0 +1,214 @@
+// Package snarkjs converts this repo's Groth16 BN254 verifying keys and
+// proofs to and from snarkjs's JSON schema (the one `snarkjs zkey export
+// verificationkey` and `snarkjs groth16 prove` produce), so proofs built
+// here can be checked with `snarkjs groth16 verify` and VKs built by the
+// wider JS/circom ecosystem can be loaded here.
+package snarkjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// g1Point and g2Point are snarkjs's affine-with-z=1 point encodings:
+// decimal-string coordinate triples, [x, y, "1"], following the Jacobian/
+// affine convention snarkjs's own JSON uses even for points it never
+// stores projectively.
+type g1Point [3]string
+type g2Point [3][2]string
+
+// VK is the snarkjs `verificationkey.json` schema for a Groth16 circuit.
+// vk_alphabeta_12 (the precomputed pairing snarkjs's JS verifier can use
+// as an optimization) is intentionally omitted: snarkjs recomputes it from
+// vk_alpha_1/vk_beta_2 when absent, so omitting it costs performance, not
+// correctness.
+type VK struct {
+	Protocol string    `json:"protocol"`
+	Curve    string    `json:"curve"`
+	NPublic  int       `json:"nPublic"`
+	VkAlpha1 g1Point   `json:"vk_alpha_1"`
+	VkBeta2  g2Point   `json:"vk_beta_2"`
+	VkGamma2 g2Point   `json:"vk_gamma_2"`
+	VkDelta2 g2Point   `json:"vk_delta_2"`
+	IC       []g1Point `json:"IC"`
+}
+
+// Proof is the snarkjs `proof.json` schema for a Groth16 proof.
+type Proof struct {
+	Protocol string  `json:"protocol"`
+	Curve    string  `json:"curve"`
+	PiA      g1Point `json:"pi_a"`
+	PiB      g2Point `json:"pi_b"`
+	PiC      g1Point `json:"pi_c"`
+}
+
+// SaveVK converts vk to snarkjs's verificationkey.json schema and writes it
+// to path.
+func SaveVK(vk *groth16_bn254.VerifyingKey, path string) error {
+	out := VK{
+		Protocol: "groth16",
+		Curve:    "bn128",
+		NPublic:  len(vk.G1.K) - 1,
+		VkAlpha1: encodeG1(&vk.G1.Alpha),
+		VkBeta2:  encodeG2(&vk.G2.Beta),
+		VkGamma2: encodeG2(&vk.G2.Gamma),
+		VkDelta2: encodeG2(&vk.G2.Delta),
+		IC:       make([]g1Point, len(vk.G1.K)),
+	}
+	for i, ic := range vk.G1.K {
+		out.IC[i] = encodeG1(&ic)
+	}
+	return writeJSON(path, out)
+}
+
+// LoadVKJSON reads a snarkjs verificationkey.json (whether produced here or
+// by the wider circom/snarkjs tooling) and reconstructs a
+// *groth16_bn254.VerifyingKey ready for sys.Verify.
+func LoadVKJSON(path string) (*groth16_bn254.VerifyingKey, error) {
+	var in VK
+	if err := readJSON(path, &in); err != nil {
+		return nil, err
+	}
+	if in.Protocol != "groth16" {
+		return nil, fmt.Errorf("snarkjs: unsupported protocol %q, want groth16", in.Protocol)
+	}
+	if in.Curve != "bn128" {
+		return nil, fmt.Errorf("snarkjs: unsupported curve %q, want bn128", in.Curve)
+	}
+
+	vk := &groth16_bn254.VerifyingKey{}
+	var err error
+	if vk.G1.Alpha, err = decodeG1(in.VkAlpha1); err != nil {
+		return nil, fmt.Errorf("vk_alpha_1: %w", err)
+	}
+	if vk.G2.Beta, err = decodeG2(in.VkBeta2); err != nil {
+		return nil, fmt.Errorf("vk_beta_2: %w", err)
+	}
+	if vk.G2.Gamma, err = decodeG2(in.VkGamma2); err != nil {
+		return nil, fmt.Errorf("vk_gamma_2: %w", err)
+	}
+	if vk.G2.Delta, err = decodeG2(in.VkDelta2); err != nil {
+		return nil, fmt.Errorf("vk_delta_2: %w", err)
+	}
+	vk.G1.K = make([]bn254.G1Affine, len(in.IC))
+	for i, p := range in.IC {
+		if vk.G1.K[i], err = decodeG1(p); err != nil {
+			return nil, fmt.Errorf("IC[%d]: %w", i, err)
+		}
+	}
+	// e(alpha, beta) and the negated gamma/delta are cached on the
+	// VerifyingKey rather than serialized (gnark's own ReadFrom recomputes
+	// them the same way); Verify silently uses zero values without this.
+	if err := vk.Precompute(); err != nil {
+		return nil, fmt.Errorf("snarkjs: precompute vk: %w", err)
+	}
+	return vk, nil
+}
+
+// SaveProof converts proof to snarkjs's proof.json schema and writes it to
+// path.
+func SaveProof(proof *groth16_bn254.Proof, path string) error {
+	out := Proof{
+		Protocol: "groth16",
+		Curve:    "bn128",
+		PiA:      encodeG1(&proof.Ar),
+		PiB:      encodeG2(&proof.Bs),
+		PiC:      encodeG1(&proof.Krs),
+	}
+	return writeJSON(path, out)
+}
+
+// SavePublic writes snarkjs's public.json schema: a flat array of decimal
+// public-input strings, in circuit order.
+func SavePublic(values []*big.Int, path string) error {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = v.String()
+	}
+	return writeJSON(path, strs)
+}
+
+func encodeG1(p *bn254.G1Affine) g1Point {
+	return g1Point{coord(&p.X), coord(&p.Y), "1"}
+}
+
+func decodeG1(p g1Point) (bn254.G1Affine, error) {
+	x, err := parseCoord(p[0])
+	if err != nil {
+		return bn254.G1Affine{}, err
+	}
+	y, err := parseCoord(p[1])
+	if err != nil {
+		return bn254.G1Affine{}, err
+	}
+	return bn254.G1Affine{X: x, Y: y}, nil
+}
+
+func encodeG2(p *bn254.G2Affine) g2Point {
+	return g2Point{
+		{coord(&p.X.A0), coord(&p.X.A1)},
+		{coord(&p.Y.A0), coord(&p.Y.A1)},
+		{"1", "0"},
+	}
+}
+
+func decodeG2(p g2Point) (bn254.G2Affine, error) {
+	x0, err := parseCoord(p[0][0])
+	if err != nil {
+		return bn254.G2Affine{}, err
+	}
+	x1, err := parseCoord(p[0][1])
+	if err != nil {
+		return bn254.G2Affine{}, err
+	}
+	y0, err := parseCoord(p[1][0])
+	if err != nil {
+		return bn254.G2Affine{}, err
+	}
+	y1, err := parseCoord(p[1][1])
+	if err != nil {
+		return bn254.G2Affine{}, err
+	}
+	var out bn254.G2Affine
+	out.X.A0, out.X.A1 = x0, x1
+	out.Y.A0, out.Y.A1 = y0, y1
+	return out, nil
+}
+
+// coord renders a base-field coordinate as the decimal string snarkjs's
+// JSON schema uses.
+func coord(e *fp.Element) string {
+	return e.BigInt(new(big.Int)).String()
+}
+
+func parseCoord(s string) (fp.Element, error) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return fp.Element{}, fmt.Errorf("%q is not a decimal field element", s)
+	}
+	var e fp.Element
+	e.SetBigInt(v)
+	return e, nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+func readJSON(path string, v interface{}) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,96 @@
+package snarkjs
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+type squareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestVKRoundTripAndProofSave(t *testing.T) {
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &squareCircuit{})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	full, err := frontend.NewWitness(&squareCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("NewWitness: %v", err)
+	}
+	proof, err := groth16.Prove(cs, pk, full)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+
+	vkBn254 := vk.(*groth16_bn254.VerifyingKey)
+	proofBn254 := proof.(*groth16_bn254.Proof)
+
+	dir := t.TempDir()
+	vkPath := filepath.Join(dir, "verificationkey.json")
+	proofPath := filepath.Join(dir, "proof.json")
+	publicPath := filepath.Join(dir, "public.json")
+
+	if err := SaveVK(vkBn254, vkPath); err != nil {
+		t.Fatalf("SaveVK: %v", err)
+	}
+	if err := SaveProof(proofBn254, proofPath); err != nil {
+		t.Fatalf("SaveProof: %v", err)
+	}
+	if err := SavePublic([]*big.Int{big.NewInt(9)}, publicPath); err != nil {
+		t.Fatalf("SavePublic: %v", err)
+	}
+
+	gotVK, err := LoadVKJSON(vkPath)
+	if err != nil {
+		t.Fatalf("LoadVKJSON: %v", err)
+	}
+	if !gotVK.G1.Alpha.Equal(&vkBn254.G1.Alpha) {
+		t.Errorf("round-tripped vk_alpha_1 does not match original")
+	}
+	if len(gotVK.G1.K) != len(vkBn254.G1.K) {
+		t.Fatalf("round-tripped IC length = %d, want %d", len(gotVK.G1.K), len(vkBn254.G1.K))
+	}
+	for i := range gotVK.G1.K {
+		if !gotVK.G1.K[i].Equal(&vkBn254.G1.K[i]) {
+			t.Errorf("round-tripped IC[%d] does not match original", i)
+		}
+	}
+
+	public, err := full.Public()
+	if err != nil {
+		t.Fatalf("Public: %v", err)
+	}
+	if err := groth16.Verify(proof, gotVK, public); err != nil {
+		t.Errorf("Verify(round-tripped vk) failed: %v", err)
+	}
+}
+
+func TestLoadVKJSONRejectsWrongProtocol(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad.json")
+	if err := writeJSON(path, VK{Protocol: "plonk", Curve: "bn128"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadVKJSON(path); err == nil {
+		t.Error("LoadVKJSON(protocol=plonk) succeeded, want error")
+	}
+}
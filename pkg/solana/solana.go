@@ -0,0 +1,213 @@
+// Package solana generates a ready-to-deploy Solana Anchor verifier program
+// from a Groth16 BN254 verifying key, and marshals proofs into the
+// big-endian field ordering Solana's alt_bn128_* syscalls expect - the
+// same encoding Ethereum's 0x06/0x07/0x08 precompiles use. A PLONK
+// scaffold (EmitPlonkProgram, in solana_plonk.go) covers the same
+// artifact layout for the --backend=plonk CLI path.
+package solana
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// MarshalProof packs a proof into the big-endian field ordering Solana's
+// alt_bn128_* syscalls expect - the same RawBytes() layout
+// groth16_bn254.Proof.MarshalSolidity uses for the EVM/groth16-solana
+// path, since both chains' precompiles share Ethereum's 0x06/0x07/0x08
+// big-endian encoding.
+//
+// Layout (same sizes as MarshalSolidity): proof_a (64 bytes, G1 negated) ||
+// proof_b (128 bytes, G2) || proof_c (64 bytes, G1).
+func MarshalProof(proof *groth16_bn254.Proof) []byte {
+	var arNeg bn254.G1Affine
+	arNeg.Neg(&proof.Ar)
+
+	arBytes := arNeg.RawBytes()
+	bsBytes := proof.Bs.RawBytes()
+	krsBytes := proof.Krs.RawBytes()
+
+	result := make([]byte, 256)
+	copy(result[0:64], arBytes[:])
+	copy(result[64:192], bsBytes[:])
+	copy(result[192:256], krsBytes[:])
+
+	return result
+}
+
+// EmitProgram generates a ready-to-deploy Anchor program (Rust source plus
+// an instruction schema) that verifies Groth16 proofs against vk using
+// Solana's alt_bn128_addition, alt_bn128_multiplication and
+// alt_bn128_pairing syscalls. Output is written under outDir/{src/lib.rs,
+// Cargo.toml, instruction_schema.json}.
+func EmitProgram(vk *groth16_bn254.VerifyingKey, outDir string) error {
+	srcDir := filepath.Join(outDir, "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		return fmt.Errorf("create program dir: %w", err)
+	}
+
+	if err := writeSolanaLibRs(vk, filepath.Join(srcDir, "lib.rs")); err != nil {
+		return fmt.Errorf("write lib.rs: %w", err)
+	}
+	if err := writeSolanaCargoToml(filepath.Join(outDir, "Cargo.toml")); err != nil {
+		return fmt.Errorf("write Cargo.toml: %w", err)
+	}
+	if err := writeSolanaInstructionSchema(vk, filepath.Join(outDir, "instruction_schema.json")); err != nil {
+		return fmt.Errorf("write instruction schema: %w", err)
+	}
+
+	return nil
+}
+
+func writeSolanaCargoToml(path string) error {
+	const tmpl = `[package]
+name = "solana-noir-verifier"
+version = "0.1.0"
+edition = "2021"
+
+[lib]
+crate-type = ["cdylib", "lib"]
+
+[dependencies]
+anchor-lang = "0.30.1"
+
+[features]
+no-entrypoint = []
+cpi = ["no-entrypoint"]
+default = []
+`
+	return os.WriteFile(path, []byte(tmpl), 0644)
+}
+
+func writeSolanaInstructionSchema(vk *groth16_bn254.VerifyingKey, path string) error {
+	nrPubInputs := len(vk.G1.K) - 1
+	schema := fmt.Sprintf(`{
+  "instruction": "verify",
+  "args": [
+    { "name": "proof", "type": { "array": ["u8", 256] } },
+    { "name": "public_inputs", "type": { "vec": { "array": ["u8", 32] } } }
+  ],
+  "nr_pubinputs": %d
+}
+`, nrPubInputs)
+	return os.WriteFile(path, []byte(schema), 0644)
+}
+
+// writeSolanaLibRs emits the Anchor program source. The verifying key's
+// alpha/beta/gamma/delta and IC[] points are embedded as a `#[account]`
+// layout so they can be loaded from an on-chain VK account rather than
+// baked into the program binary.
+func writeSolanaLibRs(vk *groth16_bn254.VerifyingKey, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	nrPubInputs := len(vk.G1.K) - 1
+
+	fmt.Fprintln(f, "// Generated Solana verifier program for a Groth16 BN254 circuit.")
+	fmt.Fprintln(f, "// Pairing check: e(A, B) = e(alpha, beta) * e(vk_x, gamma) * e(C, delta)")
+	fmt.Fprintln(f, "// where vk_x = IC[0] + sum_i public_inputs[i] * IC[i+1].")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "use anchor_lang::prelude::*;")
+	fmt.Fprintln(f, "use anchor_lang::solana_program::alt_bn128::prelude::*;")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "declare_id!(\"Verifier1111111111111111111111111111111111\");")
+	fmt.Fprintln(f, "")
+	fmt.Fprintf(f, "pub const NR_PUBINPUTS: usize = %d;\n", nrPubInputs)
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "#[account]")
+	fmt.Fprintln(f, "pub struct VerifyingKey {")
+	fmt.Fprintln(f, "    pub alpha_g1: [u8; 64],")
+	fmt.Fprintln(f, "    pub beta_g2: [u8; 128],")
+	fmt.Fprintln(f, "    pub gamma_g2: [u8; 128],")
+	fmt.Fprintln(f, "    pub delta_g2: [u8; 128],")
+	fmt.Fprintf(f, "    pub ic: [[u8; 64]; %d],\n", nrPubInputs+1)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "#[program]")
+	fmt.Fprintln(f, "pub mod solana_noir_verifier {")
+	fmt.Fprintln(f, "    use super::*;")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "    pub fn verify(")
+	fmt.Fprintln(f, "        ctx: Context<Verify>,")
+	fmt.Fprintln(f, "        proof: [u8; 256],")
+	fmt.Fprintf(f, "        public_inputs: Vec<[u8; 32]>,\n")
+	fmt.Fprintln(f, "    ) -> Result<()> {")
+	fmt.Fprintln(f, "        require_eq!(public_inputs.len(), NR_PUBINPUTS, VerifierError::BadPublicInputCount);")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "        let vk = &ctx.accounts.verifying_key;")
+	fmt.Fprintln(f, "        let a = &proof[0..64];")
+	fmt.Fprintln(f, "        let b = &proof[64..192];")
+	fmt.Fprintln(f, "        let c = &proof[192..256];")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "        // vk_x = IC[0] + sum_i public_inputs[i] * IC[i+1]")
+	fmt.Fprintln(f, "        let mut vk_x = vk.ic[0];")
+	fmt.Fprintln(f, "        for (i, input) in public_inputs.iter().enumerate() {")
+	fmt.Fprintln(f, "            let term = alt_bn128_multiplication(&[&vk.ic[i + 1][..], &input[..]].concat())")
+	fmt.Fprintln(f, "                .map_err(|_| error!(VerifierError::SyscallFailed))?;")
+	fmt.Fprintln(f, "            vk_x = alt_bn128_addition(&[&vk_x[..], &term[..]].concat())")
+	fmt.Fprintln(f, "                .map_err(|_| error!(VerifierError::SyscallFailed))?")
+	fmt.Fprintln(f, "                .try_into()")
+	fmt.Fprintln(f, "                .map_err(|_| error!(VerifierError::SyscallFailed))?;")
+	fmt.Fprintln(f, "        }")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "        // e(-A, B) * e(alpha, beta) * e(vk_x, gamma) * e(C, delta) == 1")
+	fmt.Fprintln(f, "        let mut pairing_input = Vec::with_capacity(4 * (64 + 128));")
+	fmt.Fprintln(f, "        pairing_input.extend_from_slice(a);")
+	fmt.Fprintln(f, "        pairing_input.extend_from_slice(b);")
+	fmt.Fprintln(f, "        pairing_input.extend_from_slice(&vk.alpha_g1);")
+	fmt.Fprintln(f, "        pairing_input.extend_from_slice(&vk.beta_g2);")
+	fmt.Fprintln(f, "        pairing_input.extend_from_slice(&vk_x);")
+	fmt.Fprintln(f, "        pairing_input.extend_from_slice(&vk.gamma_g2);")
+	fmt.Fprintln(f, "        pairing_input.extend_from_slice(c);")
+	fmt.Fprintln(f, "        pairing_input.extend_from_slice(&vk.delta_g2);")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "        let result = alt_bn128_pairing(&pairing_input)")
+	fmt.Fprintln(f, "            .map_err(|_| error!(VerifierError::SyscallFailed))?;")
+	fmt.Fprintln(f, "        require!(result[31] == 1, VerifierError::ProofInvalid);")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "        Ok(())")
+	fmt.Fprintln(f, "    }")
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "#[derive(Accounts)]")
+	fmt.Fprintln(f, "pub struct Verify<'info> {")
+	fmt.Fprintln(f, "    pub verifying_key: Account<'info, VerifyingKey>,")
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "#[error_code]")
+	fmt.Fprintln(f, "pub enum VerifierError {")
+	fmt.Fprintln(f, "    #[msg(\"public input count does not match the verifying key\")]")
+	fmt.Fprintln(f, "    BadPublicInputCount,")
+	fmt.Fprintln(f, "    #[msg(\"an alt_bn128 syscall failed\")]")
+	fmt.Fprintln(f, "    SyscallFailed,")
+	fmt.Fprintln(f, "    #[msg(\"proof failed the pairing check\")]")
+	fmt.Fprintln(f, "    ProofInvalid,")
+	fmt.Fprintln(f, "}")
+
+	// Keep the alpha/beta/gamma/delta/IC hex alongside the source as a quick
+	// sanity reference for whoever seeds the on-chain VerifyingKey account.
+	alphaBytes := vk.G1.Alpha.RawBytes()
+	betaBytes := vk.G2.Beta.RawBytes()
+	gammaBytes := vk.G2.Gamma.RawBytes()
+	deltaBytes := vk.G2.Delta.RawBytes()
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "// Reference VK bytes (big-endian, matches solana.MarshalProof):")
+	fmt.Fprintf(f, "// alpha_g1: %s\n", hex.EncodeToString(alphaBytes[:]))
+	fmt.Fprintf(f, "// beta_g2:  %s\n", hex.EncodeToString(betaBytes[:]))
+	fmt.Fprintf(f, "// gamma_g2: %s\n", hex.EncodeToString(gammaBytes[:]))
+	fmt.Fprintf(f, "// delta_g2: %s\n", hex.EncodeToString(deltaBytes[:]))
+	for i, ic := range vk.G1.K {
+		icBytes := ic.RawBytes()
+		fmt.Fprintf(f, "// ic[%d]:    %s\n", i, hex.EncodeToString(icBytes[:]))
+	}
+
+	return nil
+}
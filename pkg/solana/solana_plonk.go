@@ -0,0 +1,139 @@
+package solana
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
+)
+
+// EmitPlonkProgram generates a Solana Anchor program scaffold for a PLONK
+// BN254 verifying key. Unlike EmitProgram's Groth16 path, Solana has no
+// alt_bn128_* syscall that implements the PLONK verification equation (it
+// only exposes the pairing/add/mul primitives a Groth16 verifier needs), so
+// the full KZG-commitment pairing check is left as a TODO here: this emits
+// the on-chain account layout, the raw VK bytes (as a hex reference
+// comment in lib.rs), and the instruction schema - no proof blob - so a verifier can be filled in once Solana (or a precompile) can
+// run it, without blocking the rest of the --backend=plonk pipeline on
+// that gap.
+func EmitPlonkProgram(vk *plonk_bn254.VerifyingKey, outDir string) error {
+	srcDir := filepath.Join(outDir, "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		return fmt.Errorf("create program dir: %w", err)
+	}
+
+	vkBytes, err := marshalPlonkVK(vk)
+	if err != nil {
+		return fmt.Errorf("marshal plonk verifying key: %w", err)
+	}
+
+	if err := writePlonkLibRs(vk, vkBytes, filepath.Join(srcDir, "lib.rs")); err != nil {
+		return fmt.Errorf("write lib.rs: %w", err)
+	}
+	if err := writeSolanaCargoToml(filepath.Join(outDir, "Cargo.toml")); err != nil {
+		return fmt.Errorf("write Cargo.toml: %w", err)
+	}
+	if err := writePlonkInstructionSchema(vk, filepath.Join(outDir, "instruction_schema.json")); err != nil {
+		return fmt.Errorf("write instruction schema: %w", err)
+	}
+
+	return nil
+}
+
+// marshalPlonkVK serializes vk with gnark's own WriteTo, the same
+// round-trippable encoding `export-solana` already reads back via
+// readVerifyingKey for either backend.
+func marshalPlonkVK(vk *plonk_bn254.VerifyingKey) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := vk.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writePlonkInstructionSchema(vk *plonk_bn254.VerifyingKey, path string) error {
+	nrPubInputs := int(vk.NbPublicVariables)
+	schema := fmt.Sprintf(`{
+  "instruction": "verify",
+  "args": [
+    { "name": "proof", "type": "bytes" },
+    { "name": "public_inputs", "type": { "vec": { "array": ["u8", 32] } } }
+  ],
+  "nr_pubinputs": %d,
+  "backend": "plonk"
+}
+`, nrPubInputs)
+	return os.WriteFile(path, []byte(schema), 0644)
+}
+
+// writePlonkLibRs emits an Anchor program scaffold that stores the
+// gnark-serialized VK bytes verbatim (rather than field-by-field, the way
+// writeSolanaLibRs does for Groth16's small, well-known point layout) and
+// records the verify instruction shape, deferring the KZG pairing check
+// itself (see EmitPlonkProgram's doc comment).
+func writePlonkLibRs(vk *plonk_bn254.VerifyingKey, vkBytes []byte, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	nrPubInputs := int(vk.NbPublicVariables)
+
+	fmt.Fprintln(f, "// Generated Solana verifier scaffold for a PLONK BN254 circuit.")
+	fmt.Fprintln(f, "// The KZG commitment pairing check Solana would need to run this proof")
+	fmt.Fprintln(f, "// on-chain is not yet implemented here (no alt_bn128_* syscall covers it) -")
+	fmt.Fprintln(f, "// this program stores the verifying key and validates instruction shape.")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "use anchor_lang::prelude::*;")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "declare_id!(\"Verifier1111111111111111111111111111111111\");")
+	fmt.Fprintln(f, "")
+	fmt.Fprintf(f, "pub const NR_PUBINPUTS: usize = %d;\n", nrPubInputs)
+	fmt.Fprintf(f, "pub const VK_BYTES_LEN: usize = %d;\n", len(vkBytes))
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "#[account]")
+	fmt.Fprintln(f, "pub struct VerifyingKey {")
+	fmt.Fprintf(f, "    pub bytes: [u8; %d], // gnark-serialized plonk_bn254.VerifyingKey\n", len(vkBytes))
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "#[program]")
+	fmt.Fprintln(f, "pub mod solana_noir_verifier {")
+	fmt.Fprintln(f, "    use super::*;")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "    pub fn verify(")
+	fmt.Fprintln(f, "        ctx: Context<Verify>,")
+	fmt.Fprintln(f, "        proof: Vec<u8>,")
+	fmt.Fprintln(f, "        public_inputs: Vec<[u8; 32]>,")
+	fmt.Fprintln(f, "    ) -> Result<()> {")
+	fmt.Fprintln(f, "        require_eq!(public_inputs.len(), NR_PUBINPUTS, VerifierError::BadPublicInputCount);")
+	fmt.Fprintln(f, "        let _vk = &ctx.accounts.verifying_key;")
+	fmt.Fprintln(f, "        let _ = proof;")
+	fmt.Fprintln(f, "        // TODO: run the PLONK KZG pairing check against _vk and proof.")
+	fmt.Fprintln(f, "        err!(VerifierError::NotYetImplemented)")
+	fmt.Fprintln(f, "    }")
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "#[derive(Accounts)]")
+	fmt.Fprintln(f, "pub struct Verify<'info> {")
+	fmt.Fprintln(f, "    pub verifying_key: Account<'info, VerifyingKey>,")
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "#[error_code]")
+	fmt.Fprintln(f, "pub enum VerifierError {")
+	fmt.Fprintln(f, "    #[msg(\"public input count does not match the verifying key\")]")
+	fmt.Fprintln(f, "    BadPublicInputCount,")
+	fmt.Fprintln(f, "    #[msg(\"PLONK verification is not yet implemented on-chain\")]")
+	fmt.Fprintln(f, "    NotYetImplemented,")
+	fmt.Fprintln(f, "}")
+
+	fmt.Fprintln(f, "")
+	fmt.Fprintf(f, "// Reference VK bytes (gnark WriteTo encoding, %d bytes total), seed the\n", len(vkBytes))
+	fmt.Fprintln(f, "// on-chain VerifyingKey account with these via the instruction schema.")
+	fmt.Fprintf(f, "// %s\n", hex.EncodeToString(vkBytes))
+
+	return nil
+}
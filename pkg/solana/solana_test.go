@@ -0,0 +1,70 @@
+package solana
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+type squareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+// TestMarshalProofMatchesRawBytes locks in that MarshalProof concatenates
+// gnark's own big-endian RawBytes() for each coordinate (the alt_bn128_*
+// syscall convention Solana shares with Ethereum's 0x06/0x07/0x08
+// precompiles), with no byte-reversal: a previous version of this function
+// byte-swapped every coordinate under the mistaken belief that Solana's
+// syscalls are little-endian, which made every proof it emitted invalid.
+func TestMarshalProofMatchesRawBytes(t *testing.T) {
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &squareCircuit{})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	pk, _, err := groth16.Setup(cs)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	full, err := frontend.NewWitness(&squareCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("NewWitness: %v", err)
+	}
+	proof, err := groth16.Prove(cs, pk, full)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+	p := proof.(*groth16_bn254.Proof)
+
+	got := MarshalProof(p)
+	if len(got) != 256 {
+		t.Fatalf("MarshalProof returned %d bytes, want 256", len(got))
+	}
+
+	var arNeg bn254.G1Affine
+	arNeg.Neg(&p.Ar)
+	arBytes := arNeg.RawBytes()
+	bsBytes := p.Bs.RawBytes()
+	krsBytes := p.Krs.RawBytes()
+
+	if !bytes.Equal(got[0:64], arBytes[:]) {
+		t.Error("proof_a does not match negated Ar's RawBytes()")
+	}
+	if !bytes.Equal(got[64:192], bsBytes[:]) {
+		t.Error("proof_b does not match Bs's RawBytes()")
+	}
+	if !bytes.Equal(got[192:256], krsBytes[:]) {
+		t.Error("proof_c does not match Krs's RawBytes()")
+	}
+}